@@ -0,0 +1,60 @@
+package envutil
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// BindBasicAuth binds a "user:password" pair into userPtr and passPtr with a
+// optional default value. The value is split on its first colon, and both
+// parts are percent-decoded, so that "%3A" may be used for a colon in the
+// user name. The password may be empty. The returned Env is secret, and
+// renders with the password masked.
+func (n *Namespace) BindBasicAuth(name string, userPtr, passPtr *string, def ...string) *Env {
+	e := n.new(name, "basic auth")
+	e.Secret = true
+	if len(def) > 0 {
+		e.def = def[0]
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	user, pass, err := parseBasicAuth(e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			if user, pass, err := parseBasicAuth(def[0]); err == nil {
+				*userPtr, *passPtr = user, pass
+			}
+		}
+		return e
+	}
+	e.redacted = e.Value[:strings.IndexByte(e.Value, ':')] + ":****"
+	*userPtr, *passPtr = user, pass
+	return e
+}
+
+func parseBasicAuth(s string) (user, pass string, err error) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return "", "", errors.New("missing colon in user:password pair")
+	}
+	if user, err = url.PathUnescape(s[:i]); err != nil {
+		return "", "", err
+	}
+	if pass, err = url.PathUnescape(s[i+1:]); err != nil {
+		return "", "", err
+	}
+	return user, pass, nil
+}
@@ -0,0 +1,12 @@
+package envutil
+
+import "testing"
+
+func TestBindBasicAuthMasked(t *testing.T) {
+	n := NewNamespaceWithLookup("app", mapLookup(map[string]string{"APP_AUTH": "bob:correcthorsebatterystaply"}))
+	var user, pass string
+	e := n.BindBasicAuth("auth", &user, &pass)
+	if got, want := e.String(), "APP_AUTH=bob:****"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,83 @@
+package envutil
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Backoff is a retry policy, whose delay starts at Initial, and grows by
+// Factor after each attempt up to Max.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	Factor  float64
+}
+
+func (b Backoff) String() string {
+	return b.Initial.String() + "," + b.Max.String() + "," + strconv.FormatFloat(b.Factor, 'f', -1, 64)
+}
+
+// BindBackoff binds a retry policy in the form of "initial,max,factor", such
+// as "100ms,30s,2.0", into ptr with a optional default value. The factor may
+// be omitted, and defaults to 2. Policies with Initial greater than Max or
+// Factor less than 1 fall back to the default.
+func (n *Namespace) BindBackoff(name string, ptr *Backoff, def ...Backoff) *Env {
+	e := n.new(name, "backoff")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := parseBackoff(e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+func parseBackoff(s string) (Backoff, error) {
+	var b Backoff
+	ss := strings.Split(s, ",")
+	if len(ss) != 2 && len(ss) != 3 {
+		return b, errors.New("backoff must be initial,max[,factor]")
+	}
+	var err error
+	if b.Initial, err = time.ParseDuration(strings.TrimSpace(ss[0])); err != nil {
+		return b, err
+	}
+	if b.Max, err = time.ParseDuration(strings.TrimSpace(ss[1])); err != nil {
+		return b, err
+	}
+	b.Factor = 2
+	if len(ss) == 3 {
+		if b.Factor, err = strconv.ParseFloat(strings.TrimSpace(ss[2]), 64); err != nil {
+			return b, err
+		}
+	}
+	if b.Initial < 0 || b.Initial > b.Max {
+		return b, fmt.Errorf("backoff initial %v must be between 0 and max %v", b.Initial, b.Max)
+	}
+	if !(b.Factor >= 1) {
+		return b, fmt.Errorf("backoff factor %v must be at least 1", b.Factor)
+	}
+	return b, nil
+}
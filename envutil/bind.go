@@ -0,0 +1,168 @@
+package envutil
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Integer is a constraint that permits any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is a constraint that permits any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Bind binds number of type T into ptr with a optional default value. Values
+// out of the range of T are rejected like malformed ones.
+func Bind[T Integer | Float](ns *Namespace, name string, ptr *T, def ...T) *Env {
+	e := ns.new(name, typeKind(reflect.TypeOf(ptr)))
+	if len(def) > 0 {
+		e.def = formatNumber(def[0])
+	}
+	val, ok := ns.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := parseNumber[T](e.Value)
+	if err != nil {
+		if ok {
+			ns.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// BindParse binds the value converted by parse into ptr with a optional
+// default value. The default is bound if the variable is unset, or if parse
+// fails, in which case the error is recorded in Err of the returned Env.
+func BindParse[T any](ns *Namespace, name string, ptr *T, parse func(string) (T, error), def ...T) *Env {
+	e := ns.new(name, typeKind(reflect.TypeOf(ptr)))
+	if len(def) > 0 {
+		e.def = fmt.Sprint(def[0])
+	}
+	val, ok := ns.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		*ptr = def[0]
+		e.Value = e.def
+	}
+	return e
+
+BIND:
+	v, err := parse(e.Value)
+	if err != nil {
+		ns.invalid(e, err)
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// BindSlice binds the elements of the value separated by sep, and converted by
+// parse, into ptr with a optional default value. An empty sep means ",".
+// Elements are trimmed, and empty ones are dropped. If any element fails to
+// parse, the whole slice falls back to the default.
+func BindSlice[T any](ns *Namespace, name string, ptr *[]T, parse func(string) (T, error), sep string, def ...[]T) *Env {
+	if sep == "" {
+		sep = ","
+	}
+	e := ns.new(name, typeKind(reflect.TypeOf(ptr)))
+	if len(def) > 0 {
+		ss := make([]string, len(def[0]))
+		for i, v := range def[0] {
+			ss[i] = fmt.Sprint(v)
+		}
+		e.def = strings.Join(ss, sep)
+	}
+	val, ok := ns.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		*ptr = append([]T(nil), def[0]...)
+		e.Value = e.def
+	}
+	return e
+
+BIND:
+	v, err := parseSlice(e.Value, sep, parse)
+	if err != nil {
+		ns.invalid(e, err)
+		if len(def) > 0 {
+			*ptr = append([]T(nil), def[0]...)
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+func parseSlice[T any](s, sep string, parse func(string) (T, error)) ([]T, error) {
+	var v []T
+	for i, elem := range strings.Split(s, sep) {
+		if elem = strings.TrimSpace(elem); elem == "" {
+			continue
+		}
+		t, err := parse(elem)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		v = append(v, t)
+	}
+	return v, nil
+}
+
+func parseNumber[T Integer | Float](s string) (T, error) {
+	var v T
+	bits := int(unsafe.Sizeof(v)) * 8
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, bits)
+		return T(i), err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, err := strconv.ParseUint(s, 10, bits)
+		return T(i), err
+	default:
+		f, err := strconv.ParseFloat(s, bits)
+		return T(f), err
+	}
+}
+
+func formatNumber[T Integer | Float](v T) string {
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(int64(v), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(uint64(v), 10)
+	default:
+		return strconv.FormatFloat(float64(v), 'f', -1, int(unsafe.Sizeof(v))*8)
+	}
+}
@@ -0,0 +1,386 @@
+package envutil
+
+import (
+	"crypto/x509"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"time"
+)
+
+// BindIntE is like BindInt, but also returns Err of the returned Env, which is
+// non-nil if the variable is set but malformed.
+func (n *Namespace) BindIntE(name string, ptr *int64, def ...int64) (*Env, error) {
+	e := n.BindInt(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindUintE is like BindUint, but also returns Err of the returned Env.
+func (n *Namespace) BindUintE(name string, ptr *uint64, def ...uint64) (*Env, error) {
+	e := n.BindUint(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindFloatE is like BindFloat, but also returns Err of the returned Env.
+func (n *Namespace) BindFloatE(name string, ptr *float64, def ...float64) (*Env, error) {
+	e := n.BindFloat(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindBoolE is like BindBool, but also returns Err of the returned Env.
+func (n *Namespace) BindBoolE(name string, ptr *bool, def ...bool) (*Env, error) {
+	e := n.BindBool(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindIPE is like BindIP, but also returns Err of the returned Env.
+func (n *Namespace) BindIPE(name string, ptr *net.IP, def ...net.IP) (*Env, error) {
+	e := n.BindIP(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindIPNetE is like BindIPNet, but also returns Err of the returned Env.
+func (n *Namespace) BindIPNetE(name string, ptr *net.IPNet, def ...net.IPNet) (*Env, error) {
+	e := n.BindIPNet(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindTimeE is like BindTime, but also returns Err of the returned Env.
+func (n *Namespace) BindTimeE(name string, ptr *time.Time, def ...time.Time) (*Env, error) {
+	e := n.BindTime(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindDurationE is like BindDuration, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindDurationE(name string, ptr *time.Duration, def ...time.Duration) (*Env, error) {
+	e := n.BindDuration(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindAnyE is like BindAny, but also returns Err of the returned Env.
+func (n *Namespace) BindAnyE(name string, ptr any, def ...any) (*Env, error) {
+	e := n.BindAny(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindBasicAuthE is like BindBasicAuth, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindBasicAuthE(name string, userPtr, passPtr *string, def ...string) (*Env, error) {
+	e := n.BindBasicAuth(name, userPtr, passPtr, def...)
+	return e, e.Err
+}
+
+// BindBackoffE is like BindBackoff, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindBackoffE(name string, ptr *Backoff, def ...Backoff) (*Env, error) {
+	e := n.BindBackoff(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindCertPoolE is like BindCertPool, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindCertPoolE(name string, ptr **x509.CertPool, def ...string) (*Env, error) {
+	e := n.BindCertPool(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindStringMapStringE is like BindStringMapString, but also returns Err of
+// the returned Env.
+func (n *Namespace) BindStringMapStringE(name string, ptr *map[string]string, def ...map[string]string) (*Env, error) {
+	e := n.BindStringMapString(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindStringMapFloatE is like BindStringMapFloat, but also returns Err of
+// the returned Env.
+func (n *Namespace) BindStringMapFloatE(name string, ptr *map[string]float64, def ...map[string]float64) (*Env, error) {
+	e := n.BindStringMapFloat(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindCSVRecordE is like BindCSVRecord, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindCSVRecordE(name string, ptr *[]string, def ...[]string) (*Env, error) {
+	e := n.BindCSVRecord(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindDSNE is like BindDSN, but also returns Err of the returned Env.
+func (n *Namespace) BindDSNE(name string, ptr *string, def ...string) (*Env, error) {
+	e := n.BindDSN(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindStringOneOfE is like BindStringOneOf, but also returns Err of the
+// returned Env.
+func (n *Namespace) BindStringOneOfE(name string, options []string, ptr *string, idx *int, def ...string) (*Env, error) {
+	e := n.BindStringOneOf(name, options, ptr, idx, def...)
+	return e, e.Err
+}
+
+// BindGlobE is like BindGlob, but also returns Err of the returned Env.
+func (n *Namespace) BindGlobE(name string, ptr *string, def ...string) (*Env, error) {
+	e := n.BindGlob(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindGlobListE is like BindGlobList, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindGlobListE(name string, ptr *[]string, def ...[]string) (*Env, error) {
+	e := n.BindGlobList(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindGlobExpandE is like BindGlobExpand, but also returns Err of the
+// returned Env.
+func (n *Namespace) BindGlobExpandE(name string, ptr *[]string, def ...[]string) (*Env, error) {
+	e := n.BindGlobExpand(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindIntAutoE is like BindIntAuto, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindIntAutoE(name string, ptr *int64, def ...int64) (*Env, error) {
+	e := n.BindIntAuto(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindUintAutoE is like BindUintAuto, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindUintAutoE(name string, ptr *uint64, def ...uint64) (*Env, error) {
+	e := n.BindUintAuto(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindBoolOptionalE is like BindBoolOptional, but also returns Err of the
+// returned Env.
+func (n *Namespace) BindBoolOptionalE(name string, ptr **bool) (*Env, error) {
+	e := n.BindBoolOptional(name, ptr)
+	return e, e.Err
+}
+
+// BindIPv4E is like BindIPv4, but also returns Err of the returned Env.
+func (n *Namespace) BindIPv4E(name string, ptr *net.IP, def ...net.IP) (*Env, error) {
+	e := n.BindIPv4(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindIPv6E is like BindIPv6, but also returns Err of the returned Env.
+func (n *Namespace) BindIPv6E(name string, ptr *net.IP, def ...net.IP) (*Env, error) {
+	e := n.BindIPv6(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindListenAddrE is like BindListenAddr, but also returns Err of the
+// returned Env.
+func (n *Namespace) BindListenAddrE(name string, ptr *string, def ...string) (*Env, error) {
+	e := n.BindListenAddr(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindListenAddrProbeE is like BindListenAddrProbe, but also returns Err of
+// the returned Env.
+func (n *Namespace) BindListenAddrProbeE(name string, ptr *string, def ...string) (*Env, error) {
+	e := n.BindListenAddrProbe(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindNetipAddrE is like BindNetipAddr, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindNetipAddrE(name string, ptr *netip.Addr, def ...netip.Addr) (*Env, error) {
+	e := n.BindNetipAddr(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindNetipPrefixE is like BindNetipPrefix, but also returns Err of the
+// returned Env.
+func (n *Namespace) BindNetipPrefixE(name string, ptr *netip.Prefix, def ...netip.Prefix) (*Env, error) {
+	e := n.BindNetipPrefix(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindAddrPortE is like BindAddrPort, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindAddrPortE(name string, ptr *netip.AddrPort, def ...netip.AddrPort) (*Env, error) {
+	e := n.BindAddrPort(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindPortRangeE is like BindPortRange, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindPortRangeE(name string, loPtr, hiPtr *uint16, def ...[2]uint16) (*Env, error) {
+	e := n.BindPortRange(name, loPtr, hiPtr, def...)
+	return e, e.Err
+}
+
+// BindProxyURLE is like BindProxyURL, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindProxyURLE(name string, ptr **url.URL, def ...*url.URL) (*Env, error) {
+	e := n.BindProxyURL(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindIntRangeE is like BindIntRange, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindIntRangeE(name string, loPtr, hiPtr *int64, def ...[2]int64) (*Env, error) {
+	e := n.BindIntRange(name, loPtr, hiPtr, def...)
+	return e, e.Err
+}
+
+// BindIntInRangeE is like BindIntInRange, but also returns Err of the
+// returned Env.
+func (n *Namespace) BindIntInRangeE(name string, ptr *int64, min, max int64, def ...int64) (*Env, error) {
+	e := n.BindIntInRange(name, ptr, min, max, def...)
+	return e, e.Err
+}
+
+// BindUintInRangeE is like BindUintInRange, but also returns Err of the
+// returned Env.
+func (n *Namespace) BindUintInRangeE(name string, ptr *uint64, min, max uint64, def ...uint64) (*Env, error) {
+	e := n.BindUintInRange(name, ptr, min, max, def...)
+	return e, e.Err
+}
+
+// BindFloatInRangeE is like BindFloatInRange, but also returns Err of the
+// returned Env.
+func (n *Namespace) BindFloatInRangeE(name string, ptr *float64, min, max float64, def ...float64) (*Env, error) {
+	e := n.BindFloatInRange(name, ptr, min, max, def...)
+	return e, e.Err
+}
+
+// BindPercentE is like BindPercent, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindPercentE(name string, ptr *float64, def ...float64) (*Env, error) {
+	e := n.BindPercent(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindRandomSeedE is like BindRandomSeed, but also returns Err of the
+// returned Env.
+func (n *Namespace) BindRandomSeedE(name string, ptr *int64, def ...int64) (*Env, error) {
+	e := n.BindRandomSeed(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindCountE is like BindCount, but also returns Err of the returned Env.
+func (n *Namespace) BindCountE(name string, ptr *int, def ...int) (*Env, error) {
+	e := n.BindCount(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindCountRuneE is like BindCountRune, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindCountRuneE(name string, c rune, ptr *int, def ...int) (*Env, error) {
+	e := n.BindCountRune(name, c, ptr, def...)
+	return e, e.Err
+}
+
+// BindBigIntE is like BindBigInt, but also returns Err of the returned Env.
+func (n *Namespace) BindBigIntE(name string, ptr **big.Int, def ...*big.Int) (*Env, error) {
+	e := n.BindBigInt(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindBigFloatE is like BindBigFloat, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindBigFloatE(name string, prec uint, ptr **big.Float, def ...*big.Float) (*Env, error) {
+	e := n.BindBigFloat(name, prec, ptr, def...)
+	return e, e.Err
+}
+
+// BindBigRatE is like BindBigRat, but also returns Err of the returned Env.
+func (n *Namespace) BindBigRatE(name string, ptr **big.Rat, def ...*big.Rat) (*Env, error) {
+	e := n.BindBigRat(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindRateLimitE is like BindRateLimit, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindRateLimitE(name string, ptr *Rate, def ...Rate) (*Env, error) {
+	e := n.BindRateLimit(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindShellWordsE is like BindShellWords, but also returns Err of the
+// returned Env.
+func (n *Namespace) BindShellWordsE(name string, ptr *[]string, def ...[]string) (*Env, error) {
+	e := n.BindShellWords(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindSlogLevelE is like BindSlogLevel, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindSlogLevelE(name string, ptr *slog.Level, def ...slog.Level) (*Env, error) {
+	e := n.BindSlogLevel(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindRuneE is like BindRune, but also returns Err of the returned Env.
+func (n *Namespace) BindRuneE(name string, ptr *rune, def ...rune) (*Env, error) {
+	e := n.BindRune(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindDurationOrDisabledE is like BindDurationOrDisabled, but also returns
+// Err of the returned Env.
+func (n *Namespace) BindDurationOrDisabledE(name string, ptr *time.Duration, def ...time.Duration) (*Env, error) {
+	e := n.BindDurationOrDisabled(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindTimeoutDurationE is like BindTimeoutDuration, but also returns Err of
+// the returned Env.
+func (n *Namespace) BindTimeoutDurationE(name string, ptr *time.Duration, disabled time.Duration, def ...time.Duration) (*Env, error) {
+	e := n.BindTimeoutDuration(name, ptr, disabled, def...)
+	return e, e.Err
+}
+
+// BindDurationClampE is like BindDurationClamp, but also returns Err of the
+// returned Env.
+func (n *Namespace) BindDurationClampE(name string, min, max time.Duration, ptr *time.Duration, def ...time.Duration) (*Env, error) {
+	e := n.BindDurationClamp(name, min, max, ptr, def...)
+	return e, e.Err
+}
+
+// BindDurationInRangeE is like BindDurationInRange, but also returns Err of
+// the returned Env.
+func (n *Namespace) BindDurationInRangeE(name string, ptr *time.Duration, min, max time.Duration, def ...time.Duration) (*Env, error) {
+	e := n.BindDurationInRange(name, ptr, min, max, def...)
+	return e, e.Err
+}
+
+// BindTimeUnixE is like BindTimeUnix, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindTimeUnixE(name string, ptr *time.Time, def ...time.Time) (*Env, error) {
+	e := n.BindTimeUnix(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindTimeUnixMilliE is like BindTimeUnixMilli, but also returns Err of the
+// returned Env.
+func (n *Namespace) BindTimeUnixMilliE(name string, ptr *time.Time, def ...time.Time) (*Env, error) {
+	e := n.BindTimeUnixMilli(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindWeekdayE is like BindWeekday, but also returns Err of the returned
+// Env.
+func (n *Namespace) BindWeekdayE(name string, ptr *time.Weekday, def ...time.Weekday) (*Env, error) {
+	e := n.BindWeekday(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindMonthE is like BindMonth, but also returns Err of the returned Env.
+func (n *Namespace) BindMonthE(name string, ptr *time.Month, def ...time.Month) (*Env, error) {
+	e := n.BindMonth(name, ptr, def...)
+	return e, e.Err
+}
+
+// BindVarE is like BindVar, but also returns Err of the returned Env.
+func (n *Namespace) BindVarE(name string, v Value, def ...string) (*Env, error) {
+	e := n.BindVar(name, v, def...)
+	return e, e.Err
+}
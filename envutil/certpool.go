@@ -0,0 +1,68 @@
+package envutil
+
+import (
+	"crypto/x509"
+	"errors"
+	"os"
+	"strings"
+)
+
+const pemCertificatePrefix = "-----BEGIN CERTIFICATE"
+
+// BindCertPool binds a certificate pool into ptr with a optional default
+// path. The value is either a path to a PEM bundle, or the PEM content itself.
+// If neither the environment variable nor a default is given, nil is bound so
+// that callers may fall back to the system pool.
+func (n *Namespace) BindCertPool(name string, ptr **x509.CertPool, def ...string) *Env {
+	e := n.new(name, "certificate pool")
+	e.text = true
+	if len(def) > 0 {
+		e.def = def[0]
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	if strings.TrimSpace(e.Value) == "" {
+		*ptr = nil
+		return e
+	}
+	v, err := loadCertPool(e.Value)
+	if err != nil {
+		n.invalid(e, err)
+		if ok && len(def) > 0 {
+			if v, err := loadCertPool(def[0]); err == nil {
+				*ptr = v
+				return e
+			}
+		}
+		*ptr = nil
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+func loadCertPool(s string) (*x509.CertPool, error) {
+	s = strings.TrimSpace(s)
+	data := []byte(s)
+	if !strings.HasPrefix(s, pemCertificatePrefix) {
+		b, err := os.ReadFile(s)
+		if err != nil {
+			return nil, err
+		}
+		data = b
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errors.New("no certificates found in PEM data")
+	}
+	return pool, nil
+}
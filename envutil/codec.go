@@ -0,0 +1,338 @@
+package envutil
+
+import (
+	"encoding"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type codec struct {
+	parse  func(string) (any, error)
+	format func(any) string
+}
+
+var (
+	codecMu sync.RWMutex
+	codecs  = make(map[reflect.Type]codec)
+)
+
+// RegisterCodec registers parse and format as the conversions of values of
+// type t from and to string, which are used by BindAny. The value returned by
+// parse, and passed to format, must be of type t. Registering a codec for a
+// type replaces any codec registered before, including built-in ones.
+func RegisterCodec(t reflect.Type, parse func(string) (any, error), format func(any) string) {
+	codecMu.Lock()
+	defer codecMu.Unlock()
+	codecs[t] = codec{parse, format}
+}
+
+func lookupCodec(t reflect.Type) (codec, bool) {
+	codecMu.RLock()
+	defer codecMu.RUnlock()
+	c, ok := codecs[t]
+	return c, ok
+}
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// codecFor returns the codec for values of type t. A codec registered for t
+// is preferred. Otherwise, if t or a pointer to it implements
+// encoding.TextUnmarshaler, values are parsed by UnmarshalText, and formatted
+// by MarshalText if implemented. Otherwise, if the underlying type of t is a
+// string, boolean or number, its codec is used with a conversion.
+func codecFor(t reflect.Type) (codec, bool) {
+	if c, ok := lookupCodec(t); ok {
+		return c, true
+	}
+	switch {
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		return codec{func(s string) (any, error) {
+			v := reflect.New(t)
+			err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+			return v.Elem().Interface(), err
+		}, formatText}, true
+	case t.Kind() == reflect.Pointer && t.Implements(textUnmarshalerType):
+		return codec{func(s string) (any, error) {
+			v := reflect.New(t.Elem())
+			err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+			return v.Interface(), err
+		}, formatText}, true
+	}
+	for _, base := range basicTypes {
+		if t.Kind() != base.Kind() || t == base {
+			continue
+		}
+		c, ok := lookupCodec(base)
+		if !ok {
+			return codec{}, false
+		}
+		return codec{func(s string) (any, error) {
+			v, err := c.parse(s)
+			if err != nil {
+				return nil, err
+			}
+			return reflect.ValueOf(v).Convert(t).Interface(), nil
+		}, func(v any) string {
+			return c.format(reflect.ValueOf(v).Convert(base).Interface())
+		}}, true
+	}
+	return codec{}, false
+}
+
+var basicTypes = []reflect.Type{
+	reflect.TypeOf(""), reflect.TypeOf(false),
+	reflect.TypeOf(int(0)), reflect.TypeOf(int8(0)), reflect.TypeOf(int16(0)),
+	reflect.TypeOf(int32(0)), reflect.TypeOf(int64(0)),
+	reflect.TypeOf(uint(0)), reflect.TypeOf(uint8(0)), reflect.TypeOf(uint16(0)),
+	reflect.TypeOf(uint32(0)), reflect.TypeOf(uint64(0)),
+	reflect.TypeOf(float32(0)), reflect.TypeOf(float64(0)),
+}
+
+// formatText formats v by MarshalText if it implements encoding.TextMarshaler,
+// or else by fmt.Sprint.
+func formatText(v any) string {
+	if m, ok := v.(encoding.TextMarshaler); ok {
+		if b, err := m.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+// BindAny binds into ptr, which must be a non-nil pointer, with a optional
+// default value of the type ptr points to. The value is converted by the
+// codec registered for that type. Built-in codecs cover strings, booleans,
+// numbers, and every other type that has a dedicated binder, such as
+// time.Duration and net.IP. Types without a registered codec are supported if
+// they implement encoding.TextUnmarshaler, or their underlying type is a
+// string, boolean or number. Any problem, including a missing codec, is
+// recorded in Err of the returned Env.
+func (n *Namespace) BindAny(name string, ptr any, def ...any) *Env {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		e := n.new(name, "")
+		e.Err = fmt.Errorf("envutil: BindAny of non-pointer or nil %T", ptr)
+		return e
+	}
+	c, found := codecFor(rv.Type().Elem())
+	if !found {
+		e := n.new(name, typeKind(rv.Type()))
+		e.Err = fmt.Errorf("envutil: no codec registered for %v", rv.Type().Elem())
+		return e
+	}
+	return n.bindCodec(name, c, rv.Elem(), false, def...)
+}
+
+// bindCodec binds into v, which must be settable, with a optional default
+// value of the type of v. The value is converted by c, and is secret if so
+// is secret.
+func (n *Namespace) bindCodec(name string, c codec, v reflect.Value, secret bool, def ...any) *Env {
+	e := n.new(name, typeKind(v.Type()))
+	e.Secret = secret
+	e.text = v.Kind() == reflect.String
+	if len(def) > 0 && reflect.TypeOf(def[0]) != v.Type() {
+		e.Err = fmt.Errorf("envutil: default of type %T for %v", def[0], v.Type())
+		return e
+	}
+	if len(def) > 0 {
+		e.def = c.format(def[0])
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	x, err := c.parse(e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			v.Set(reflect.ValueOf(def[0]))
+		}
+		return e
+	}
+	v.Set(reflect.ValueOf(x))
+	return e
+}
+
+// collectionCodec returns the codec for t if it is a slice, or a map, whose
+// keys and elements have codecs. Elements are separated by sep, and map
+// entries are in the form of "key=value".
+func collectionCodec(t reflect.Type, sep string) (codec, bool) {
+	switch t.Kind() {
+	case reflect.Slice:
+		ec, ok := codecFor(t.Elem())
+		if !ok {
+			return codec{}, false
+		}
+		return codec{func(s string) (any, error) {
+			v := reflect.MakeSlice(t, 0, 0)
+			for i, elem := range strings.Split(s, sep) {
+				if elem = strings.TrimSpace(elem); elem == "" {
+					continue
+				}
+				x, err := ec.parse(elem)
+				if err != nil {
+					return nil, fmt.Errorf("element %d: %w", i, err)
+				}
+				v = reflect.Append(v, reflect.ValueOf(x))
+			}
+			return v.Interface(), nil
+		}, func(x any) string {
+			v := reflect.ValueOf(x)
+			ss := make([]string, v.Len())
+			for i := range ss {
+				ss[i] = ec.format(v.Index(i).Interface())
+			}
+			return strings.Join(ss, sep)
+		}}, true
+	case reflect.Map:
+		kc, ok := codecFor(t.Key())
+		if !ok {
+			return codec{}, false
+		}
+		ec, ok := codecFor(t.Elem())
+		if !ok {
+			return codec{}, false
+		}
+		return codec{func(s string) (any, error) {
+			v := reflect.MakeMap(t)
+			for i, pair := range strings.Split(s, sep) {
+				if pair = strings.TrimSpace(pair); pair == "" {
+					continue
+				}
+				j := strings.IndexByte(pair, '=')
+				if j < 0 {
+					return nil, fmt.Errorf("element %d: missing '=' in %q", i, pair)
+				}
+				k, err := kc.parse(strings.TrimSpace(pair[:j]))
+				if err != nil {
+					return nil, fmt.Errorf("element %d: %w", i, err)
+				}
+				x, err := ec.parse(strings.TrimSpace(pair[j+1:]))
+				if err != nil {
+					return nil, fmt.Errorf("element %d: %w", i, err)
+				}
+				v.SetMapIndex(reflect.ValueOf(k), reflect.ValueOf(x))
+			}
+			return v.Interface(), nil
+		}, func(x any) string {
+			v := reflect.ValueOf(x)
+			ss := make([]string, 0, v.Len())
+			iter := v.MapRange()
+			for iter.Next() {
+				ss = append(ss, kc.format(iter.Key().Interface())+"="+ec.format(iter.Value().Interface()))
+			}
+			sort.Strings(ss)
+			return strings.Join(ss, sep)
+		}}, true
+	}
+	return codec{}, false
+}
+
+func registerNumberCodec[T Integer | Float]() {
+	var v T
+	RegisterCodec(reflect.TypeOf(v), func(s string) (any, error) {
+		return parseNumber[T](s)
+	}, func(v any) string {
+		return formatNumber(v.(T))
+	})
+}
+
+func init() {
+	RegisterCodec(reflect.TypeOf(""), func(s string) (any, error) {
+		return s, nil
+	}, func(v any) string {
+		return v.(string)
+	})
+	RegisterCodec(reflect.TypeOf(false), func(s string) (any, error) {
+		return parseBool(s)
+	}, func(v any) string {
+		return strconv.FormatBool(v.(bool))
+	})
+	registerNumberCodec[int]()
+	registerNumberCodec[int8]()
+	registerNumberCodec[int16]()
+	registerNumberCodec[int32]()
+	registerNumberCodec[int64]()
+	registerNumberCodec[uint]()
+	registerNumberCodec[uint8]()
+	registerNumberCodec[uint16]()
+	registerNumberCodec[uint32]()
+	registerNumberCodec[uint64]()
+	registerNumberCodec[float32]()
+	registerNumberCodec[float64]()
+	RegisterCodec(reflect.TypeOf(time.Duration(0)), func(s string) (any, error) {
+		return time.ParseDuration(strings.TrimSpace(s))
+	}, func(v any) string {
+		return v.(time.Duration).String()
+	})
+	RegisterCodec(reflect.TypeOf(time.Time{}), func(s string) (any, error) {
+		return time.Parse(time.RFC3339Nano, strings.TrimSpace(s))
+	}, func(v any) string {
+		return v.(time.Time).Format(time.RFC3339Nano)
+	})
+	RegisterCodec(reflect.TypeOf(time.Weekday(0)), func(s string) (any, error) {
+		i, err := parseCalendarName(s, 0, 6, func(i int) string { return time.Weekday(i).String() })
+		return time.Weekday(i), err
+	}, func(v any) string {
+		return v.(time.Weekday).String()
+	})
+	RegisterCodec(reflect.TypeOf(time.Month(0)), func(s string) (any, error) {
+		i, err := parseCalendarName(s, 1, 12, func(i int) string { return time.Month(i).String() })
+		return time.Month(i), err
+	}, func(v any) string {
+		return v.(time.Month).String()
+	})
+	RegisterCodec(reflect.TypeOf(net.IP{}), func(s string) (any, error) {
+		v := net.ParseIP(strings.TrimSpace(s))
+		if v == nil {
+			return nil, fmt.Errorf("invalid IP %q", s)
+		}
+		return v, nil
+	}, func(v any) string {
+		return v.(net.IP).String()
+	})
+	RegisterCodec(reflect.TypeOf(net.IPNet{}), func(s string) (any, error) {
+		_, v, err := net.ParseCIDR(strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+		return *v, nil
+	}, func(v any) string {
+		n := v.(net.IPNet)
+		return n.String()
+	})
+	RegisterCodec(reflect.TypeOf(&url.URL{}), func(s string) (any, error) {
+		return url.Parse(strings.TrimSpace(s))
+	}, func(v any) string {
+		return v.(*url.URL).String()
+	})
+	RegisterCodec(reflect.TypeOf(Backoff{}), func(s string) (any, error) {
+		return parseBackoff(s)
+	}, func(v any) string {
+		return v.(Backoff).String()
+	})
+	RegisterCodec(reflect.TypeOf(Rate{}), func(s string) (any, error) {
+		return parseRate(s)
+	}, func(v any) string {
+		return v.(Rate).String()
+	})
+}
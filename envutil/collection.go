@@ -0,0 +1,271 @@
+package envutil
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BindStringSet binds comma separated strings into ptr as a set with a
+// optional default value. Elements are trimmed, and empty elements are
+// dropped. The default set is copied, so that ptr never aliases it. The
+// members are listed in sorted order by Members of the returned Env, so that
+// dumps are stable across runs, while its Value is the variable as set.
+func (n *Namespace) BindStringSet(name string, ptr *map[string]struct{}, def ...map[string]struct{}) *Env {
+	return n.bindStringSet(name, false, ptr, def...)
+}
+
+// BindStringSetFold is like BindStringSet, but members, including those of
+// the default, are lowercased.
+func (n *Namespace) BindStringSetFold(name string, ptr *map[string]struct{}, def ...map[string]struct{}) *Env {
+	return n.bindStringSet(name, true, ptr, def...)
+}
+
+func (n *Namespace) bindStringSet(name string, fold bool, ptr *map[string]struct{}, def ...map[string]struct{}) *Env {
+	e := n.new(name, "string set")
+	if len(def) > 0 {
+		e.def = strings.Join(sortedKeys(copySet(def[0], fold)), ",")
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		*ptr = copySet(def[0], fold)
+		e.Value = e.def
+		e.members = sortedKeys(*ptr)
+		return e
+	}
+
+BIND:
+	v := make(map[string]struct{})
+	for _, s := range strings.Split(e.Value, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		if fold {
+			s = strings.ToLower(s)
+		}
+		v[s] = struct{}{}
+	}
+	e.members = sortedKeys(v)
+	*ptr = v
+	return e
+}
+
+// BindPathList binds a list of paths separated by os.PathListSeparator, as in
+// $PATH, into ptr with a optional default value. Empty elements are dropped,
+// and each element is cleaned with filepath.Clean.
+func (n *Namespace) BindPathList(name string, ptr *[]string, def ...[]string) *Env {
+	return n.bindPathList(name, os.PathListSeparator, ptr, def...)
+}
+
+func (n *Namespace) bindPathList(name string, sep rune, ptr *[]string, def ...[]string) *Env {
+	e := n.new(name, "path list")
+	if len(def) > 0 {
+		e.def = strings.Join(def[0], string(sep))
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		*ptr = append([]string(nil), def[0]...)
+		e.Value = e.def
+		return e
+	}
+
+BIND:
+	*ptr = splitPathList(e.Value, sep)
+	return e
+}
+
+func splitPathList(s string, sep rune) []string {
+	var v []string
+	for _, p := range strings.Split(s, string(sep)) {
+		if p != "" {
+			v = append(v, filepath.Clean(p))
+		}
+	}
+	return v
+}
+
+// BindStringMapString binds a JSON object of strings, such as
+// {"a":"x","b":"y"}, into ptr with a optional default value. This suits keys
+// containing commas or equal signs. Anything but a flat object of strings,
+// including nested objects, non-string values and null, falls back to the
+// default. An empty object binds an empty, non-nil map.
+func (n *Namespace) BindStringMapString(name string, ptr *map[string]string, def ...map[string]string) *Env {
+	e := n.new(name, "map[string]string")
+	if len(def) > 0 {
+		b, _ := json.Marshal(def[0])
+		e.def = string(b)
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	var v map[string]string
+	if err := unmarshalJSONObject(e.Value, &v); err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = copyMap(def[0])
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// BindStringMapFloat binds a JSON object of numbers, such as
+// {"a":1.5,"b":2}, into ptr with a optional default value. Anything but a
+// flat object of numbers falls back to the default.
+func (n *Namespace) BindStringMapFloat(name string, ptr *map[string]float64, def ...map[string]float64) *Env {
+	e := n.new(name, "map[string]float64")
+	if len(def) > 0 {
+		b, _ := json.Marshal(def[0])
+		e.def = string(b)
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	var v map[string]float64
+	if err := unmarshalJSONObject(e.Value, &v); err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = copyMap(def[0])
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// BindCSVRecord binds a single CSV record, such as `"last, first",age,city`,
+// into ptr with a optional default value. Fields are parsed by encoding/csv,
+// so that quoted fields may contain commas and escaped quotes. Malformed
+// input, or more than one record, falls back to the default.
+func (n *Namespace) BindCSVRecord(name string, ptr *[]string, def ...[]string) *Env {
+	e := n.new(name, "CSV record")
+	if len(def) > 0 {
+		e.def = formatCSVRecord(def[0])
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		*ptr = append([]string(nil), def[0]...)
+		e.Value = e.def
+		return e
+	}
+
+BIND:
+	v, err := parseCSVRecord(e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = append([]string(nil), def[0]...)
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+func parseCSVRecord(s string) ([]string, error) {
+	r := csv.NewReader(strings.NewReader(s))
+	v, err := r.Read()
+	if err == io.EOF {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Read(); err != io.EOF {
+		return nil, errors.New("more than one CSV record")
+	}
+	return v, nil
+}
+
+func formatCSVRecord(v []string) string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Write(v)
+	w.Flush()
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// unmarshalJSONObject unmarshals s into the map pointed to by ptr, and
+// rejects null, which would otherwise leave the map nil.
+func unmarshalJSONObject(s string, ptr interface{}) error {
+	if strings.TrimSpace(s) == "null" {
+		return errors.New("JSON object expected, got null")
+	}
+	return json.Unmarshal([]byte(s), ptr)
+}
+
+// copyMap returns a copy of m, so that a bound default never aliases it.
+func copyMap[V any](m map[string]V) map[string]V {
+	if m == nil {
+		return nil
+	}
+	v := make(map[string]V, len(m))
+	for k, x := range m {
+		v[k] = x
+	}
+	return v
+}
+
+// copySet returns a copy of m, with its members lowercased if fold is true.
+func copySet(m map[string]struct{}, fold bool) map[string]struct{} {
+	v := make(map[string]struct{}, len(m))
+	for k := range m {
+		if fold {
+			k = strings.ToLower(k)
+		}
+		v[k] = struct{}{}
+	}
+	return v
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,65 @@
+package envutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBindPathList(t *testing.T) {
+	tests := []struct {
+		sep  rune
+		in   string
+		want []string
+	}{
+		{':', "/usr/bin::/bin/:./x/../y", []string{"/usr/bin", "/bin", "y"}},
+		{':', "/a;/b", []string{"/a;/b"}},
+		{';', "/usr/bin;;/bin/;./x/../y", []string{"/usr/bin", "/bin", "y"}},
+		{';', "/a:/b", []string{"/a:/b"}},
+		{':', "", nil},
+	}
+	for _, tt := range tests {
+		n := NewNamespaceWithLookup("app", mapLookup(map[string]string{"APP_PATH": tt.in}))
+		var got []string
+		n.bindPathList("path", tt.sep, &got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("bindPathList(%q, %q) = %q, want %q", tt.sep, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBindStringSetFold(t *testing.T) {
+	tests := []struct {
+		env       map[string]string
+		want      map[string]struct{}
+		wantValue string
+	}{
+		{map[string]string{"APP_TAGS": " B, a ,,A"}, map[string]struct{}{"a": {}, "b": {}}, " B, a ,,A"},
+		{nil, map[string]struct{}{"x": {}, "y": {}}, "x,y"},
+	}
+	for _, tt := range tests {
+		n := NewNamespaceWithLookup("app", mapLookup(tt.env))
+		var got map[string]struct{}
+		e := n.BindStringSetFold("tags", &got, map[string]struct{}{"Y": {}, "X": {}})
+		if !reflect.DeepEqual(got, tt.want) || e.Value != tt.wantValue {
+			t.Errorf("BindStringSetFold with %v = %v, Value %q; want %v, Value %q", tt.env, got, e.Value, tt.want, tt.wantValue)
+		}
+		if members := e.Members(); !reflect.DeepEqual(members, sortedKeys(tt.want)) {
+			t.Errorf("BindStringSetFold with %v: Members() = %q, want %q", tt.env, members, sortedKeys(tt.want))
+		}
+	}
+}
+
+func TestBindStringMapDefaultCopied(t *testing.T) {
+	n := NewNamespaceWithLookup("app", mapLookup(map[string]string{"APP_LABELS": "[", "APP_WEIGHTS": "["}))
+	labels := map[string]string{"a": "x"}
+	weights := map[string]float64{"a": 1}
+	var gotLabels map[string]string
+	var gotWeights map[string]float64
+	n.BindStringMapString("labels", &gotLabels, labels)
+	n.BindStringMapFloat("weights", &gotWeights, weights)
+	gotLabels["a"] = "changed"
+	gotWeights["a"] = 2
+	if labels["a"] != "x" || weights["a"] != 1 {
+		t.Errorf("defaults changed through bound maps: %v, %v", labels, weights)
+	}
+}
@@ -0,0 +1,50 @@
+package envutil
+
+import (
+	"errors"
+)
+
+// Collector collects the errors of binding variables in a Namespace, so that
+// every problem can be reported at once.
+type Collector struct {
+	n    *Namespace
+	errs []error
+}
+
+// NewCollector creates a Collector for variables bound in n.
+func NewCollector(n *Namespace) *Collector {
+	return &Collector{n: n}
+}
+
+// Namespace returns the Namespace of c.
+func (c *Collector) Namespace() *Namespace {
+	return c.n
+}
+
+// Collect records err, or Err of e if err is nil, and returns e. It accepts
+// the results of binders returning (*Env, error) as they are:
+//
+//	c.Collect(ns.BindEmail("alert email", &cfg.Email))
+func (c *Collector) Collect(e *Env, err error) *Env {
+	if err == nil {
+		err = e.Err
+	}
+	if err != nil {
+		c.errs = append(c.errs, newParseError(e, err))
+	}
+	return e
+}
+
+// Require records an error if the variable of e is unset or empty, and
+// returns e.
+func (c *Collector) Require(e *Env) *Env {
+	if val, ok := c.n.lookupEnv(e.Name); !ok || val == "" {
+		c.errs = append(c.errs, &ParseError{Name: e.Name, Value: val, Err: ErrRequired})
+	}
+	return e
+}
+
+// Err returns every recorded error joined by errors.Join, or nil if none.
+func (c *Collector) Err() error {
+	return errors.Join(c.errs...)
+}
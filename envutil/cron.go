@@ -0,0 +1,140 @@
+package envutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BindCron binds a cron expression of 5 fields, minute, hour, day of month,
+// month and day of week, into ptr with a optional default value. Each field
+// is a comma-separated list of "*", values or ranges, each with an optional
+// "/step", and months and days of week may be given by their three-letter
+// names. Macros such as "@daily" are accepted as well. The expression is only
+// validated, and bound with its fields separated by single spaces. Invalid
+// expressions fall back to the default, and the error is returned.
+func (n *Namespace) BindCron(name string, ptr *string, def ...string) (*Env, error) {
+	return n.bindCron(name, false, ptr, def...)
+}
+
+// BindCronSeconds is like BindCron, but expects a leading field of seconds,
+// for 6 fields in total.
+func (n *Namespace) BindCronSeconds(name string, ptr *string, def ...string) (*Env, error) {
+	return n.bindCron(name, true, ptr, def...)
+}
+
+func (n *Namespace) bindCron(name string, seconds bool, ptr *string, def ...string) (*Env, error) {
+	e := n.new(name, "cron expression")
+	if len(def) > 0 {
+		e.def = def[0]
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := parseCron(e.Value, seconds)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e, e.Err
+	}
+	*ptr = v
+	return e, nil
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var cronFields = []cronField{
+	{"second", 0, 59, nil},
+	{"minute", 0, 59, nil},
+	{"hour", 0, 23, nil},
+	{"day of month", 1, 31, nil},
+	{"month", 1, 12, []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{"day of week", 0, 7, []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+var cronMacros = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true,
+}
+
+func parseCron(s string, seconds bool) (string, error) {
+	ss := strings.Fields(s)
+	if len(ss) == 1 && cronMacros[strings.ToLower(ss[0])] {
+		return strings.ToLower(ss[0]), nil
+	}
+	fields := cronFields
+	if !seconds {
+		fields = fields[1:]
+	}
+	if len(ss) != len(fields) {
+		return "", fmt.Errorf("cron expression %q has %d fields, want %d", s, len(ss), len(fields))
+	}
+	for i, f := range fields {
+		if err := f.validate(ss[i]); err != nil {
+			return "", fmt.Errorf("%s field %q: %w", f.name, ss[i], err)
+		}
+	}
+	return strings.Join(ss, " "), nil
+}
+
+func (f cronField) validate(s string) error {
+	for _, term := range strings.Split(s, ",") {
+		rng, step, stepped := strings.Cut(term, "/")
+		if stepped {
+			if v, err := strconv.Atoi(step); err != nil || v <= 0 {
+				return fmt.Errorf("invalid step %q", step)
+			}
+		}
+		if rng == "*" {
+			continue
+		}
+		lo, hi, ranged := strings.Cut(rng, "-")
+		a, err := f.value(lo)
+		if err != nil {
+			return err
+		}
+		if !ranged {
+			continue
+		}
+		b, err := f.value(hi)
+		if err != nil {
+			return err
+		}
+		if a > b {
+			return fmt.Errorf("invalid range %q", rng)
+		}
+	}
+	return nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, f.min, f.max)
+	}
+	return v, nil
+}
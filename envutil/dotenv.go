@@ -0,0 +1,204 @@
+package envutil
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseEnv parses the variables defined in r in the .env format, and returns
+// them in order of definition. The format follows sourcing by bash as closely
+// as practical, without any expansion:
+//
+//   - Lines end with either LF or CRLF. Blank lines, and lines starting with
+//     "#" after optional white space, are ignored.
+//   - A definition is "KEY=VALUE", optionally preceded by "export ". It is
+//     split on the first "=", so VALUE may contain "=". KEY must consist of
+//     letters, digits and underscores, and not start with a digit.
+//   - An unquoted VALUE extends to the end of the line, where a "#" preceded
+//     by white space starts a comment. Surrounding white space is trimmed.
+//   - A VALUE in single quotes is taken literally up to the next single
+//     quote, and may span lines.
+//   - A VALUE in double quotes extends up to the next unescaped double quote,
+//     and may span lines. A backslash only escapes '"', '\\', '$', '`', or a
+//     newline, which is removed along with it. Other backslashes are literal.
+//   - After a closing quote, only white space or a comment may follow.
+//
+// Any line that violates these rules is reported with its number.
+func ParseEnv(r io.Reader) ([]*Env, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &envParser{s: strings.ReplaceAll(string(b), "\r\n", "\n"), line: 1}
+	var envs []*Env
+	for {
+		e, err := p.next()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", p.line, err)
+		}
+		if e == nil {
+			return envs, nil
+		}
+		envs = append(envs, e)
+	}
+}
+
+// LookupEnvs returns a LookupFunc which retrieves values from envs, such as
+// those returned by ParseEnv. Later definitions take precedence.
+func LookupEnvs(envs []*Env) LookupFunc {
+	m := make(map[string]string, len(envs))
+	for _, e := range envs {
+		m[e.Name] = e.Value
+	}
+	return func(key string) (string, bool) {
+		v, ok := m[key]
+		return v, ok
+	}
+}
+
+type envParser struct {
+	s    string
+	i    int
+	line int
+}
+
+// next returns the next definition, or nil at the end of input.
+func (p *envParser) next() (*Env, error) {
+	for p.i < len(p.s) {
+		end := strings.IndexByte(p.s[p.i:], '\n')
+		if end < 0 {
+			end = len(p.s) - p.i
+		}
+		line := strings.TrimSpace(p.s[p.i : p.i+end])
+		if line == "" || line[0] == '#' {
+			p.skipLine(end)
+			continue
+		}
+		return p.definition()
+	}
+	return nil, nil
+}
+
+func (p *envParser) skipLine(n int) {
+	p.i += n
+	if p.i < len(p.s) {
+		p.i++
+		p.line++
+	}
+}
+
+func (p *envParser) definition() (*Env, error) {
+	p.skipBlank()
+	if rest := p.s[p.i:]; strings.HasPrefix(rest, "export ") || strings.HasPrefix(rest, "export\t") {
+		p.i += len("export")
+		p.skipBlank()
+	}
+	eq := strings.IndexByte(p.s[p.i:], '=')
+	nl := strings.IndexByte(p.s[p.i:], '\n')
+	if eq < 0 || (nl >= 0 && nl < eq) {
+		return nil, fmt.Errorf("missing '=' in definition")
+	}
+	key := strings.TrimRight(p.s[p.i:p.i+eq], " \t")
+	if !isEnvKey(key) {
+		return nil, fmt.Errorf("invalid variable name %q", key)
+	}
+	p.i += eq + 1
+	p.skipBlank()
+
+	var (
+		val string
+		err error
+	)
+	switch {
+	case p.i < len(p.s) && p.s[p.i] == '\'':
+		val, err = p.singleQuoted()
+	case p.i < len(p.s) && p.s[p.i] == '"':
+		val, err = p.doubleQuoted()
+	default:
+		val = p.unquoted()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := p.endOfLine(); err != nil {
+		return nil, err
+	}
+	return &Env{Name: key, Value: val}, nil
+}
+
+func (p *envParser) skipBlank() {
+	for p.i < len(p.s) && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+func (p *envParser) singleQuoted() (string, error) {
+	j := strings.IndexByte(p.s[p.i+1:], '\'')
+	if j < 0 {
+		return "", fmt.Errorf("unterminated single quote")
+	}
+	v := p.s[p.i+1 : p.i+1+j]
+	p.line += strings.Count(v, "\n")
+	p.i += j + 2
+	return v, nil
+}
+
+func (p *envParser) doubleQuoted() (string, error) {
+	var b strings.Builder
+	for p.i++; p.i < len(p.s); p.i++ {
+		c := p.s[p.i]
+		switch {
+		case c == '"':
+			p.i++
+			return b.String(), nil
+		case c == '\\' && p.i+1 < len(p.s) && strings.IndexByte("\"\\$`\n", p.s[p.i+1]) >= 0:
+			p.i++
+			if c = p.s[p.i]; c == '\n' {
+				p.line++
+				continue
+			}
+		case c == '\n':
+			p.line++
+		}
+		b.WriteByte(c)
+	}
+	return "", fmt.Errorf("unterminated double quote")
+}
+
+func (p *envParser) unquoted() string {
+	start := p.i
+	for p.i < len(p.s) && p.s[p.i] != '\n' {
+		if p.s[p.i] == '#' && p.i > start && (p.s[p.i-1] == ' ' || p.s[p.i-1] == '\t') {
+			break
+		}
+		p.i++
+	}
+	return strings.TrimRight(p.s[start:p.i], " \t")
+}
+
+// endOfLine consumes the rest of the line, which must be blank or a comment.
+func (p *envParser) endOfLine() error {
+	p.skipBlank()
+	end := strings.IndexByte(p.s[p.i:], '\n')
+	if end < 0 {
+		end = len(p.s) - p.i
+	}
+	if rest := p.s[p.i : p.i+end]; rest != "" && rest[0] != '#' {
+		return fmt.Errorf("unexpected %q after value", rest)
+	}
+	p.skipLine(end)
+	return nil
+}
+
+func isEnvKey(s string) bool {
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		return false
+	}
+	for _, c := range s {
+		if c != '_' && (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
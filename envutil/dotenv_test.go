@@ -0,0 +1,87 @@
+package envutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseEnv(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string // NAME=value pairs
+		err  bool
+	}{
+		{in: "A=1\r\nB=2\r\n", want: []string{"A=1", "B=2"}},
+		{in: "export A=1\nexport\tB=2", want: []string{"A=1", "B=2"}},
+		{in: "A=x=y==", want: []string{"A=x=y=="}},
+		{in: "A=\"x # y\" # comment\nB='#'", want: []string{"A=x # y", "B=#"}},
+		{in: "A=x#y # comment", want: []string{"A=x#y"}},
+		{in: "A=\"a\r\nb\"", want: []string{"A=a\nb"}},
+		{in: "A=\"unterminated\nB=2", err: true},
+		{in: "A='unterminated", err: true},
+		{in: "1A=x", err: true},
+		{in: "A", err: true},
+	}
+	for _, tt := range tests {
+		envs, err := ParseEnv(strings.NewReader(tt.in))
+		if tt.err {
+			if err == nil {
+				t.Errorf("ParseEnv(%q) = %v, want error", tt.in, envs)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseEnv(%q): %v", tt.in, err)
+			continue
+		}
+		var got []string
+		for _, e := range envs {
+			got = append(got, e.Name+"="+e.Value)
+		}
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("ParseEnv(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func FuzzParseEnv(f *testing.F) {
+	for _, s := range []string{
+		"A=1\r\nB=2\r\n",
+		"export A=1\nexport\tB=2",
+		"A=x=y==",
+		"A=\"x # y\" # comment\nB='#'",
+		"A=\"a\\\"b\\\\c\\\nd\"",
+		"A=\"unterminated\nB=2",
+		"A='unterminated",
+		"# comment\n\n  A = 1 \n",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		envs, err := ParseEnv(strings.NewReader(s))
+		if err != nil {
+			return
+		}
+		for _, e := range envs {
+			if !isEnvKey(e.Name) {
+				t.Fatalf("ParseEnv(%q) returned invalid name %q", s, e.Name)
+			}
+		}
+		// CRLF line endings parse the same as LF.
+		if strings.Contains(s, "\r") {
+			return
+		}
+		crlf, err := ParseEnv(strings.NewReader(strings.ReplaceAll(s, "\n", "\r\n")))
+		if err != nil {
+			t.Fatalf("ParseEnv(%q) with CRLF: %v", s, err)
+		}
+		if len(crlf) != len(envs) {
+			t.Fatalf("ParseEnv(%q) with CRLF = %v, want %v", s, crlf, envs)
+		}
+		for i := range envs {
+			if crlf[i].Name != envs[i].Name || crlf[i].Value != envs[i].Value {
+				t.Fatalf("ParseEnv(%q) with CRLF = %v, want %v", s, crlf, envs)
+			}
+		}
+	})
+}
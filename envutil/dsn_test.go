@@ -0,0 +1,22 @@
+package envutil
+
+import "testing"
+
+func TestRedactDSN(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"postgres://app:hunter2@db/prod", "postgres://app:****@db/prod"},
+		{"postgres://db/prod?password=hunter2hunter2", "postgres://db/prod?password=****"},
+		{"postgres://db/prod?sslmode=verify-full&sslpassword=topsecret123", "postgres://db/prod?sslmode=verify-full&sslpassword=****"},
+		{"postgres://db/prod?pass%77ord=hunter2", "postgres://db/prod?password=****"},
+		{"host=db user=app password=hunter2", "host=db user=app password=****"},
+		{"host=db sslpassword='top secret' sslmode=require", "host=db sslpassword=**** sslmode=require"},
+	}
+	for _, tt := range tests {
+		got, err := redactDSN(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("redactDSN(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
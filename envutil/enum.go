@@ -0,0 +1,108 @@
+package envutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BindEnumInt binds the integer which the value is mapped to in mapping into
+// ptr with a optional default value. Names are matched case-insensitively,
+// and an integer is accepted as is if it is one of the mapped values.
+func (n *Namespace) BindEnumInt(name string, mapping map[string]int64, ptr *int64, def ...int64) *Env {
+	e := n.new(name, "enum")
+	if len(def) > 0 {
+		e.def = strconv.FormatInt(def[0], 10)
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := parseEnumInt(mapping, e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// BindEnumIntE is like BindEnumInt, but also returns the error if the value
+// is set but unknown to mapping.
+func (n *Namespace) BindEnumIntE(name string, mapping map[string]int64, ptr *int64, def ...int64) (*Env, error) {
+	e := n.BindEnumInt(name, mapping, ptr, def...)
+	return e, e.Err
+}
+
+// BindStringOneOf binds the option which the value matches case-insensitively
+// into ptr, in its canonical form as given in options, and its index in
+// options into idx, unless idx is nil. If the variable is unset, or matches
+// no option, the optional default value is bound into ptr, and -1 into idx.
+func (n *Namespace) BindStringOneOf(name string, options []string, ptr *string, idx *int, def ...string) *Env {
+	e := n.new(name, "option")
+	if len(def) > 0 {
+		e.def = def[0]
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	setIndex(idx, -1)
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+		*ptr = def[0]
+	}
+	return e
+
+BIND:
+	for i, opt := range options {
+		if strings.EqualFold(strings.TrimSpace(e.Value), opt) {
+			*ptr = opt
+			setIndex(idx, i)
+			return e
+		}
+	}
+	n.invalid(e, fmt.Errorf("%q is not one of %s", e.Value, strings.Join(options, ", ")))
+	setIndex(idx, -1)
+	if len(def) > 0 {
+		*ptr = def[0]
+	}
+	return e
+}
+
+func setIndex(idx *int, i int) {
+	if idx != nil {
+		*idx = i
+	}
+}
+
+func parseEnumInt(mapping map[string]int64, s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	for k, v := range mapping {
+		if strings.EqualFold(k, s) {
+			return v, nil
+		}
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		for _, v := range mapping {
+			if v == i {
+				return v, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unknown enum value %q", s)
+}
@@ -0,0 +1,13 @@
+package envutil
+
+import "testing"
+
+func TestBindStringOneOfNilIndex(t *testing.T) {
+	n := NewNamespaceWithLookup("app", mapLookup(map[string]string{"APP_MODE": "FAST", "APP_BAD": "x"}))
+	var mode string
+	if e := n.BindStringOneOf("mode", []string{"slow", "fast"}, &mode, nil); e.Err != nil || mode != "fast" {
+		t.Errorf("BindStringOneOf = %q, %v; want %q", mode, e.Err, "fast")
+	}
+	n.BindStringOneOf("bad", []string{"slow", "fast"}, &mode, nil, "slow")
+	n.BindStringOneOf("unset", []string{"slow", "fast"}, &mode, nil)
+}
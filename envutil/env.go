@@ -1,6 +1,10 @@
 package envutil
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -9,11 +13,261 @@ import (
 type Env struct {
 	Name  string
 	Value string
+	// Err records why Value could not be bound, if any. It is set whenever a
+	// value taken from the environment is rejected, whether the binder fell
+	// back to the default or left the destination untouched.
+	Err error
+	// Secret marks Value as sensitive, so that it is masked wherever it is
+	// rendered, including String, JSON and error messages.
+	Secret bool
+	// Clamped reports whether the bound value was clamped into range.
+	Clamped bool
+	// Source records where Value came from.
+	Source Source
+
+	redacted string
+	text     bool // bound as free text, or otherwise meaningful when empty
+	required bool
+	empty    bool           // empty values satisfy required
+	kind     string         // type of values, such as "duration"
+	def      string         // formatted default value, if any
+	set      func(v string) // binds v, for string binders
+	options  []string
+	members  []string   // sorted members, for set binders
+	ns       *Namespace // namespace which bound e, for its OnError hooks
 }
 
 func (e *Env) String() string {
-	if strings.Contains(e.Value, "\"") {
-		return e.Name + "=" + strconv.Quote(e.Value)
+	v := e.safeValue()
+	if strings.Contains(v, "\"") {
+		return e.Name + "=" + strconv.Quote(v)
+	}
+	return e.Name + "=" + v
+}
+
+// Explain returns e as by String, annotated with where its value came from,
+// such as "APP_PORT=8080 (from env)", "APP_HOST=0.0.0.0 (default)" or
+// "APP_DEBUG=<unset>". Secret values are masked.
+func (e *Env) Explain() string {
+	switch e.Source {
+	case SourceUnset:
+		return e.Name + "=<unset>"
+	case SourceEnv:
+		return e.String() + " (from env)"
+	}
+	return e.String() + " (" + e.Source.String() + ")"
+}
+
+// Equal reports whether e and other bind the same variable to the same value
+// with the same secrecy, from the same Source. A value taken from the
+// environment is therefore not equal to the same value used as the default.
+func (e *Env) Equal(other *Env) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+	return e.Name == other.Name && e.Value == other.Value && e.Secret == other.Secret &&
+		e.Source == other.Source
+}
+
+// Required marks the variable of e as required, so that Namespace.Err,
+// Validate and CheckRequired report it if it is unset or empty, regardless
+// of any default. It returns e.
+func (e *Env) Required() *Env {
+	e.required = true
+	return e
+}
+
+// Validate calls fn with Value of e, which is either the value set or the
+// default, and records the error it returns, if any, in Err along with
+// earlier ones, so that it is reported by Namespace.Err and Validate. It is
+// not called if the value set is already rejected. It returns e.
+func (e *Env) Validate(fn func(value string) error) *Env {
+	if e.Source == SourceInvalid {
+		return e
+	}
+	if err := fn(e.Value); err != nil {
+		e.reject(err)
+	}
+	return e
+}
+
+// NonEmpty validates Value of e, as by Validate, to be neither empty nor
+// blank. It returns e.
+func (e *Env) NonEmpty() *Env {
+	return e.Validate(func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return errEmpty
+		}
+		return nil
+	})
+}
+
+// MatchRegexp validates Value of e, as by Validate, against the regular
+// expression pattern. An unset variable without a default is not validated,
+// which is left to Required. It panics if pattern fails to compile. It
+// returns e.
+func (e *Env) MatchRegexp(pattern string) *Env {
+	re := regexp.MustCompile(pattern)
+	if e.Source == SourceUnset {
+		return e
+	}
+	return e.Validate(func(value string) error {
+		if !re.MatchString(value) {
+			return fmt.Errorf("%q does not match %s", value, pattern)
+		}
+		return nil
+	})
+}
+
+// reject records err in Err as a ParseError, along with earlier errors, and
+// passes it to the OnError hooks of the namespace which bound e.
+func (e *Env) reject(err error) {
+	err = newParseError(e, err)
+	if e.Err == nil {
+		e.Err = err
+	} else {
+		e.Err = errors.Join(e.Err, err)
+	}
+	if e.ns != nil {
+		e.ns.notify(e, err)
+	}
+}
+
+// OneOf checks that Value of e is one of values, case-insensitively, and binds
+// the matching one as given in values. Otherwise, it records an error in Err,
+// and binds the default, or the empty string if there is none, provided that
+// e is returned by a binder of plain strings, such as BindString. The values
+// are listed in Registry.Manifest. An unset variable without a default is not
+// checked, which is left to Required. It returns e.
+func (e *Env) OneOf(values ...string) *Env {
+	return e.oneOf(strings.EqualFold, values)
+}
+
+// OneOfExact is like OneOf, but is case-sensitive.
+func (e *Env) OneOfExact(values ...string) *Env {
+	return e.oneOf(func(a, b string) bool { return a == b }, values)
+}
+
+func (e *Env) oneOf(eq func(a, b string) bool, values []string) *Env {
+	e.options = append([]string(nil), values...)
+	if e.Source == SourceUnset || e.Source == SourceInvalid {
+		return e
+	}
+	for _, v := range values {
+		if eq(e.Value, v) {
+			if e.set != nil {
+				e.set(v)
+			}
+			return e
+		}
+	}
+	e.Source = SourceInvalid
+	e.reject(fmt.Errorf("%q is not one of %s", e.Value, strings.Join(values, ", ")))
+	if e.set != nil {
+		e.set(e.def)
+	}
+	return e
+}
+
+// Members returns the members bound by BindStringSet or BindStringSetFold in
+// sorted order, which is stable across runs, or nil for other binders.
+func (e *Env) Members() []string {
+	return append([]string(nil), e.members...)
+}
+
+// AllowEmpty makes a variable set to the empty string satisfy Required. It
+// returns e.
+func (e *Env) AllowEmpty() *Env {
+	e.empty = true
+	return e
+}
+
+// missing reports whether e fails to satisfy Required.
+func (e *Env) missing() bool {
+	switch e.Source {
+	case SourceEnv:
+		return e.Value == "" && !e.empty
+	case SourceInvalid:
+		return false
+	}
+	return true
+}
+
+// Source describes where the value of an Env came from.
+type Source int
+
+const (
+	// SourceUnset means that the variable is unset, and no default is given.
+	SourceUnset Source = iota
+	// SourceEnv means that the value is taken from the environment.
+	SourceEnv
+	// SourceDefault means that the variable is unset, and the default is
+	// used.
+	SourceDefault
+	// SourceInvalid means that the value taken from the environment is
+	// rejected, and the default, if any, is used instead.
+	SourceInvalid
+)
+
+var sourceNames = [...]string{"unset", "env", "default", "invalid"}
+
+func (s Source) String() string {
+	if s < 0 || int(s) >= len(sourceNames) {
+		return "Source(" + strconv.Itoa(int(s)) + ")"
+	}
+	return sourceNames[s]
+}
+
+// safeValue returns Value, or its masked form if e is secret.
+func (e *Env) safeValue() string {
+	if !e.Secret {
+		return e.Value
+	}
+	if e.redacted != "" {
+		return e.redacted
+	}
+	return mask(e.Value)
+}
+
+// mask masks s entirely, except for its first and last characters if it is
+// longer than 8 characters, to aid debugging.
+func mask(s string) string {
+	rs := []rune(s)
+	if len(rs) <= 8 {
+		return strings.Repeat("*", len(rs))
+	}
+	return string(rs[0]) + strings.Repeat("*", len(rs)-2) + string(rs[len(rs)-1])
+}
+
+type envJSON struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Secret bool   `json:"secret,omitempty"`
+	Source string `json:"source"`
+}
+
+// MarshalJSON implements json.Marshaler. The value of a secret Env is masked.
+func (e *Env) MarshalJSON() ([]byte, error) {
+	return json.Marshal(envJSON{
+		Name:   e.Name,
+		Value:  e.safeValue(),
+		Secret: e.Secret,
+		Source: e.Source.String(),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *Env) UnmarshalJSON(data []byte) error {
+	var v envJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	e.Name, e.Value, e.Secret = v.Name, v.Value, v.Secret
+	e.Source = SourceUnset
+	for i, name := range sourceNames {
+		if v.Source == name {
+			e.Source = Source(i)
+		}
 	}
-	return e.Name + "=" + e.Value
+	return nil
 }
@@ -0,0 +1,133 @@
+package envutil
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// mapLookup returns a LookupFunc which retrieves values from m.
+func mapLookup(m map[string]string) LookupFunc {
+	return func(key string) (string, bool) {
+		v, ok := m[key]
+		return v, ok
+	}
+}
+
+func TestOneOfUnset(t *testing.T) {
+	n := NewNamespaceWithLookup("app", mapLookup(nil))
+	var s string
+	e := n.BindString("mode", &s).OneOf("fast", "slow")
+	if e.Source != SourceUnset || e.Err != nil {
+		t.Errorf("OneOf on unset variable: Source = %v, Err = %v; want unset, nil", e.Source, e.Err)
+	}
+	if err := n.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
+
+func TestMatchRegexpUnset(t *testing.T) {
+	n := NewNamespaceWithLookup("app", mapLookup(map[string]string{"APP_ID": "x-1"}))
+	var s string
+	if e := n.BindString("region", &s).MatchRegexp(`^[a-z]+$`); e.Err != nil {
+		t.Errorf("MatchRegexp on unset variable: Err = %v, want nil", e.Err)
+	}
+	if e := n.BindString("id", &s).MatchRegexp(`^[a-z]+$`); e.Err == nil {
+		t.Errorf("MatchRegexp(%q) on %q: Err = nil, want error", `^[a-z]+$`, "x-1")
+	}
+}
+
+func TestErrRequired(t *testing.T) {
+	n := NewNamespaceWithLookup("app", mapLookup(map[string]string{"APP_HOST": ""}))
+	var s string
+	n.BindString("token", &s).Required()
+	n.BindString("host", &s, "localhost").Required()
+	n.BindString("name", &s, "turret").Required()
+	err := n.Err()
+	if !errors.Is(err, ErrRequired) {
+		t.Fatalf("Err() = %v, want ErrRequired", err)
+	}
+	if got, want := err.Error(), "APP_TOKEN: required variable is not set\nAPP_HOST: required variable is not set\nAPP_NAME: required variable is not set"; got != want {
+		t.Errorf("Err() = %q, want %q", got, want)
+	}
+}
+
+func TestSecretErrEscaped(t *testing.T) {
+	const secret = `12"34567890`
+	n := NewNamespaceWithLookup("app", mapLookup(map[string]string{"APP_PIN": secret, "APP_CODE": secret}))
+	var c struct {
+		Pin int `secret:"true"`
+	}
+	var code int64
+	e := n.BindInt("code", &code)
+	e.Secret = true
+	for _, err := range []error{n.BindStruct(&c), n.Err(), e.Err} {
+		if err == nil {
+			t.Fatal("err = nil, want error")
+		}
+		if s := err.Error(); strings.Contains(s, "34567") {
+			t.Errorf("error %q reveals secret %q", s, secret)
+		}
+	}
+	if !errors.Is(e.Err, strconv.ErrSyntax) {
+		t.Errorf("Err = %v, want strconv.ErrSyntax", e.Err)
+	}
+}
+
+func TestEnvEqualSource(t *testing.T) {
+	a := &Env{Name: "APP_X", Value: "1", Source: SourceEnv}
+	b := &Env{Name: "APP_X", Value: "1", Source: SourceDefault}
+	if a.Equal(b) {
+		t.Error("Equal of Envs from different sources = true")
+	}
+	b.Source = SourceEnv
+	if !a.Equal(b) {
+		t.Error("Equal of identical Envs = false")
+	}
+}
+
+func TestSecretOutputs(t *testing.T) {
+	const secret = `hunter2"secret!`
+	type config struct {
+		Token int64 `secret:"true" default:"12345678901"`
+	}
+	bind := func(val string, hook func(e *Env, err error)) (*Namespace, *Env) {
+		n := NewNamespaceWithLookup("app", mapLookup(map[string]string{"APP_TOKEN": val}))
+		if hook != nil {
+			n.OnError(hook)
+		}
+		n.BindStruct(&config{})
+		return n, n.Registry().Lookup("APP_TOKEN")
+	}
+	var hooked string
+	n, e := bind(secret, func(e *Env, err error) { hooked = err.Error() })
+	other, _ := bind(`hunter3"secret!`, nil)
+	js, err := e.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, got string
+	}{
+		{"String", e.String()},
+		{"Explain", e.Explain()},
+		{"MarshalJSON", string(js)},
+		{"Env.Err", e.Err.Error()},
+		{"Namespace.Err", n.Err().Error()},
+		{"OnError", hooked},
+		{"Manifest", fmt.Sprint(n.Registry().Manifest())},
+		{"Diff", fmt.Sprint(Diff(n.Registry(), other.Registry()))},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(tt.got, "***") {
+			t.Errorf("%s = %q, want masked value", tt.name, tt.got)
+		}
+		for _, s := range []string{"unter", "2345678"} {
+			if strings.Contains(tt.got, s) {
+				t.Errorf("%s = %q, reveals %q", tt.name, tt.got, s)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package envutil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+var (
+	// ErrRequired is matched by errors.Is for errors reporting that a
+	// required variable is not set.
+	ErrRequired = errors.New("required variable is not set")
+	// ErrInvalid is matched by errors.Is for errors reporting that the value
+	// of a variable is rejected.
+	ErrInvalid = errors.New("invalid value")
+)
+
+// ParseError records a variable which failed to bind. Binders record it in
+// Err of the returned Env.
+type ParseError struct {
+	Name  string // full variable name
+	Value string // rejected value, masked if secret
+	Kind  string // type of the value, such as "duration" or "uint16"
+	Err   error  // reason
+
+	env *Env // bound Env, whose secrecy is checked whenever e is rendered
+}
+
+func (e *ParseError) Error() string {
+	if errors.Is(e.Err, ErrRequired) {
+		return e.Name + ": " + e.Err.Error()
+	}
+	kind := e.Kind
+	if kind == "" {
+		kind = "value"
+	}
+	if e.env != nil && e.env.Secret {
+		// Parsers quote and escape the value in their messages, so it
+		// cannot be reliably masked there. Only the cause is rendered.
+		return fmt.Sprintf("%s=%q: invalid %s: %v", e.Name, e.env.safeValue(), kind, secretCause(e.Err))
+	}
+	return fmt.Sprintf("%s=%q: invalid %s: %v", e.Name, e.Value, kind, e.Err)
+}
+
+// secretCauses are the causes which are rendered for secret values, as they
+// never include the value.
+var secretCauses = []error{strconv.ErrSyntax, strconv.ErrRange, errEmpty}
+
+var errMalformed = errors.New("malformed value")
+
+// secretCause returns the cause of err among secretCauses, or errMalformed.
+func secretCause(err error) error {
+	for _, cause := range secretCauses {
+		if errors.Is(err, cause) {
+			return cause
+		}
+	}
+	return errMalformed
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalid, and e is not about a missing
+// required variable.
+func (e *ParseError) Is(target error) bool {
+	return target == ErrInvalid && !errors.Is(e.Err, ErrRequired)
+}
+
+// newParseError returns a ParseError about e for err, unless err is one
+// already. Its message masks the value whenever e is secret, even if e is
+// made secret later.
+func newParseError(e *Env, err error) error {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		return err
+	}
+	return &ParseError{Name: e.Name, Value: e.safeValue(), Kind: e.kind, Err: err, env: e}
+}
+
+// typeKind returns the kind of values of type t for ParseError, which is the
+// name of t, or of its element if it is a pointer.
+func typeKind(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.String()
+}
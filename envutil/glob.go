@@ -0,0 +1,93 @@
+package envutil
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// BindGlob binds a filepath.Match pattern into ptr with a optional default
+// value. Patterns with malformed syntax fall back to the default.
+func (n *Namespace) BindGlob(name string, ptr *string, def ...string) *Env {
+	e := n.new(name, "glob pattern")
+	if len(def) > 0 {
+		e.def = def[0]
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	if err := validateGlob(e.Value); err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = e.Value
+	return e
+}
+
+// BindGlobList binds comma separated filepath.Match patterns into ptr with a
+// optional default value. Patterns are trimmed, and empty ones are dropped.
+// If any pattern has malformed syntax, the whole list falls back to the
+// default.
+func (n *Namespace) BindGlobList(name string, ptr *[]string, def ...[]string) *Env {
+	return BindSlice(n, name, ptr, func(s string) (string, error) {
+		return s, validateGlob(s)
+	}, ",", def...)
+}
+
+// BindGlobExpand binds the paths of files matching a filepath.Glob pattern,
+// such as "/var/log/app/*.log", into ptr with a optional default value. A
+// pattern matching nothing binds an empty slice, while a malformed pattern
+// falls back to the default. Value of the returned Env keeps the pattern, so
+// that what was requested can be logged along with what matched.
+func (n *Namespace) BindGlobExpand(name string, ptr *[]string, def ...[]string) *Env {
+	e := n.new(name, "glob pattern")
+	if len(def) > 0 {
+		e.def = strings.Join(def[0], ",")
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		*ptr = append([]string(nil), def[0]...)
+		e.Value = e.def
+	}
+	return e
+
+BIND:
+	v, err := filepath.Glob(e.Value)
+	if err != nil {
+		n.invalid(e, fmt.Errorf("invalid pattern %q: %w", e.Value, err))
+		if len(def) > 0 {
+			*ptr = append([]string(nil), def[0]...)
+		}
+		return e
+	}
+	if v == nil {
+		v = []string{}
+	}
+	*ptr = v
+	return e
+}
+
+func validateGlob(pattern string) error {
+	if _, err := filepath.Match(pattern, "probe"); err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package envutil
+
+import (
+	"net/mail"
+)
+
+// BindEmail binds an email address into ptr with a optional default value.
+// The value is parsed by mail.ParseAddress, so that "Ops <ops@example.com>" is
+// accepted, and only the bare address is bound. Invalid addresses fall back
+// to the default, and the error is returned.
+func (n *Namespace) BindEmail(name string, ptr *string, def ...string) (*Env, error) {
+	e := n.new(name, "email address")
+	if len(def) > 0 {
+		e.def = def[0]
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := mail.ParseAddress(e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e, e.Err
+	}
+	*ptr = v.Address
+	return e, nil
+}
@@ -0,0 +1,88 @@
+package envutil
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// MustBind returns the value of variable name in n, converted by parse and
+// checked by validate, which may be nil. If the variable is unset, or it fails
+// to parse or validate, the default is returned instead. MustBind panics if
+// no default is given in such cases.
+func MustBind[T any](n *Namespace, name string, parse func(string) (T, error), validate func(T) error, def ...T) T {
+	e := n.new(name, typeKind(reflect.TypeOf((*T)(nil))))
+	if len(def) > 0 {
+		e.def = fmt.Sprint(def[0])
+	}
+	val, ok := n.resolve(e)
+	if !ok {
+		if len(def) > 0 {
+			e.Source = SourceDefault
+			e.Value = e.def
+			return def[0]
+		}
+		panic(fmt.Sprintf("envutil: %s is not set", e.Name))
+	}
+	e.Value = val
+	v, err := parse(val)
+	if err == nil && validate != nil {
+		err = validate(v)
+	}
+	if err != nil {
+		n.invalid(e, err)
+		if len(def) > 0 {
+			return def[0]
+		}
+		panic("envutil: " + e.Err.Error())
+	}
+	return v
+}
+
+// MustBindString is like BindString, but panics if the variable is unset and
+// no default is given.
+func (n *Namespace) MustBindString(name string, ptr *string, def ...string) *Env {
+	return n.must(n.BindString(name, ptr, def...), len(def) > 0)
+}
+
+// MustBindInt is like BindInt, but panics if the variable is malformed, or
+// unset and no default is given.
+func (n *Namespace) MustBindInt(name string, ptr *int64, def ...int64) *Env {
+	return n.must(n.BindInt(name, ptr, def...), len(def) > 0)
+}
+
+// MustBindUint is like BindUint, but panics if the variable is malformed, or
+// unset and no default is given.
+func (n *Namespace) MustBindUint(name string, ptr *uint64, def ...uint64) *Env {
+	return n.must(n.BindUint(name, ptr, def...), len(def) > 0)
+}
+
+// MustBindFloat is like BindFloat, but panics if the variable is malformed,
+// or unset and no default is given.
+func (n *Namespace) MustBindFloat(name string, ptr *float64, def ...float64) *Env {
+	return n.must(n.BindFloat(name, ptr, def...), len(def) > 0)
+}
+
+// MustBindBool is like BindBool, but panics if the variable is malformed, or
+// unset and no default is given.
+func (n *Namespace) MustBindBool(name string, ptr *bool, def ...bool) *Env {
+	return n.must(n.BindBool(name, ptr, def...), len(def) > 0)
+}
+
+// MustBindDuration is like BindDuration, but panics if the variable is
+// malformed, or unset and no default is given.
+func (n *Namespace) MustBindDuration(name string, ptr *time.Duration, def ...time.Duration) *Env {
+	return n.must(n.BindDuration(name, ptr, def...), len(def) > 0)
+}
+
+// must panics if e failed to bind, or if its variable is unset and hasDef is
+// false. Otherwise, it returns e.
+func (n *Namespace) must(e *Env, hasDef bool) *Env {
+	if e.Err != nil {
+		panic("envutil: " + e.Err.Error())
+	}
+	if _, ok := n.lookupEnv(e.Name); !ok && !hasDef {
+		panic(fmt.Sprintf("envutil: %s is not set", e.Name))
+	}
+	return e
+}
@@ -1,6 +1,8 @@
 package envutil
 
 import (
+	"errors"
+	"fmt"
 	"net"
 	"os"
 	"strconv"
@@ -10,24 +12,259 @@ import (
 
 // Namespace is a binder which is used for binding environment variables.
 type Namespace struct {
-	s string
+	s            string
+	r            *Registry
+	lookup       LookupFunc
+	warn         func(msg string)
+	emptyAsUnset bool
+	strict       bool
+	negative     bool
+	exclusive    [][]string
+	onError      []func(e *Env, err error)
 }
 
-func (n *Namespace) new(s string) *Env {
-	ss := []string{n.s, strings.ReplaceAll(s, " ", "_")}
-	return &Env{Name: strings.ToUpper(strings.Join(ss, "_"))}
+// LookupFunc retrieves the value of the variable named by key, and reports
+// whether it is present, like os.LookupEnv.
+type LookupFunc func(key string) (string, bool)
+
+func (n *Namespace) lookupEnv(key string) (string, bool) {
+	n.r.use(key)
+	val, ok := n.lookupRaw(key)
+	if ok && val == "" && n.emptyAsUnset {
+		return "", false
+	}
+	return val, ok
+}
+
+func (n *Namespace) lookupRaw(key string) (string, bool) {
+	if n.lookup == nil {
+		return os.LookupEnv(key)
+	}
+	return n.lookup(key)
+}
+
+// resolve looks up the variable of e, and marks e as sourced from the
+// environment if it is set.
+func (n *Namespace) resolve(e *Env) (string, bool) {
+	val, ok := n.lookupEnv(e.Name)
+	if ok {
+		e.Source = SourceEnv
+	}
+	return val, ok
+}
+
+// invalid records that the value of e is rejected for err, as a ParseError,
+// with the value masked in its message if e is secret.
+func (n *Namespace) invalid(e *Env, err error) {
+	e.Err = newParseError(e, err)
+	e.Source = SourceInvalid
+	n.notify(e, e.Err)
+}
+
+// notify passes err about e to the OnError hooks of n.
+func (n *Namespace) notify(e *Env, err error) {
+	for _, fn := range n.onError {
+		fn(e, err)
+	}
+}
+
+func (n *Namespace) key(s string) string {
+	return keyPrefix(n.s) + normalizeKey(s)
+}
+
+// normalizeKey uppercases s, and joins its words, which are separated by any
+// run of spaces and underscores, with a single underscore.
+func normalizeKey(s string) string {
+	ss := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '_'
+	})
+	return strings.ToUpper(strings.Join(ss, "_"))
+}
+
+// keyPrefix returns the prefix of variables in the namespace s, which is
+// empty for the root namespace.
+func keyPrefix(s string) string {
+	if s == "" {
+		return ""
+	}
+	return s + "_"
+}
+
+// new returns a new Env for variable s, whose values are of kind, such as
+// "duration", and records it in the registry.
+func (n *Namespace) new(s, kind string) *Env {
+	e := &Env{Name: n.key(s), kind: kind, ns: n}
+	n.r.add(e)
+	return e
+}
+
+func (n *Namespace) newText(s string) *Env {
+	e := n.new(s, "string")
+	e.text = true
+	return e
+}
+
+// TreatEmptyAsUnset sets whether every binder of n treats a variable set to
+// the empty string as if it were unset, so that the default applies. It is
+// disabled by default, in which case an empty value is bound as is, or
+// rejected like any other malformed value.
+func (n *Namespace) TreatEmptyAsUnset(v bool) *Namespace {
+	n.emptyAsUnset = v
+	return n
+}
+
+// Strict sets whether n is strict, in which case Err also reports every
+// variable which is set but empty, or blank, even if its binder accepted it,
+// or treated it as unset. String variables are exempt, and so are those whose
+// binders give the empty value a meaning, such as BindProxyURL for no proxy.
+// It is disabled by default.
+func (n *Namespace) Strict(v bool) *Namespace {
+	n.strict = v
+	return n
+}
+
+// AllowNegativeDurations sets whether BindDurationInRange accepts negative
+// durations within its range. They are rejected by default.
+func (n *Namespace) AllowNegativeDurations(v bool) *Namespace {
+	n.negative = v
+	return n
+}
+
+// OnDeprecated sets fn to receive a warning whenever a value is sourced from a
+// deprecated variable. Warnings are discarded by default.
+func (n *Namespace) OnDeprecated(fn func(msg string)) *Namespace {
+	n.warn = fn
+	return n
+}
+
+// OnError adds fn to the hooks which are called, in the order they are added,
+// whenever a value is rejected by a binder of n, or by Env.Validate, OneOf
+// and the like on an Env bound by n, with the Env and the error, in which
+// the value is masked if the Env is secret. Hooks are called
+// synchronously, before the binder returns.
+func (n *Namespace) OnError(fn func(e *Env, err error)) *Namespace {
+	n.onError = append(n.onError, fn)
+	return n
+}
+
+// MutuallyExclusive declares the variables named by names, as passed to
+// binders, as a group of which at most one may be set. Validate reports every
+// group which has more than one of its variables set; defaults do not count.
+// It may be called more than once to declare several groups.
+func (n *Namespace) MutuallyExclusive(names ...string) *Namespace {
+	group := make([]string, len(names))
+	for i, name := range names {
+		group[i] = n.key(name)
+	}
+	n.exclusive = append(n.exclusive, group)
+	return n
+}
+
+// Prefix returns the prefix of n, such as "APP", without the separator. It
+// is empty for the root namespace.
+func (n *Namespace) Prefix() string {
+	return n.s
 }
 
+// String returns the pattern of variable names in n, such as "APP_*".
+func (n *Namespace) String() string {
+	return keyPrefix(n.s) + "*"
+}
+
+// Registry returns the registry of all variables bound by n.
+func (n *Namespace) Registry() *Registry {
+	return n.r
+}
+
+// Err returns every error recorded by the Envs bound by n, joined with
+// errors.Join, each naming the variable and its offending value, along with
+// every variable marked by Env.Required which is unset or empty. It returns
+// nil if all of them were bound cleanly.
+func (n *Namespace) Err() error {
+	return n.check(false)
+}
+
+// Validate is like Err, but also reports groups declared by
+// MutuallyExclusive with more than one variable set. Failures of validators
+// attached by Env.Validate are recorded in Err at once, and are therefore
+// reported by both. It is meant to be called once all variables are bound.
+func (n *Namespace) Validate() error {
+	return n.check(true)
+}
+
+// CheckRequired returns an error listing every variable marked by
+// Env.Required which is unset or empty, joined with errors.Join, or nil if
+// there is none.
+func (n *Namespace) CheckRequired() error {
+	var errs []error
+	for _, e := range n.r.All() {
+		if e.required && e.missing() {
+			errs = append(errs, &ParseError{Name: e.Name, Value: e.safeValue(), Err: ErrRequired})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Namespace) check(validate bool) error {
+	var errs []error
+	for _, e := range n.r.All() {
+		if e.Err != nil {
+			errs = append(errs, newParseError(e, e.Err))
+			continue
+		}
+		if n.strict && !e.text {
+			if val, ok := n.lookupRaw(e.Name); ok && strings.TrimSpace(val) == "" {
+				errs = append(errs, &ParseError{Name: e.Name, Value: val, Kind: e.kind, Err: errEmpty})
+				continue
+			}
+		}
+		if e.required && e.missing() {
+			errs = append(errs, &ParseError{Name: e.Name, Value: e.safeValue(), Err: ErrRequired})
+		}
+	}
+	if validate {
+		for _, group := range n.exclusive {
+			var set []string
+			for _, key := range group {
+				if n.isSet(key) {
+					set = append(set, key)
+				}
+			}
+			if len(set) > 1 {
+				errs = append(errs, fmt.Errorf("mutually exclusive variables are set: %s", strings.Join(set, ", ")))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isSet reports whether the variable key is set in the environment, in which
+// case a bound Env takes its value from it.
+func (n *Namespace) isSet(key string) bool {
+	if e := n.r.Lookup(key); e != nil {
+		return e.Source == SourceEnv || e.Source == SourceInvalid
+	}
+	val, ok := n.lookupRaw(key)
+	return ok && !(val == "" && n.emptyAsUnset)
+}
+
+var errEmpty = errors.New("empty value")
+
 // BindString binds string into ptr with a optional default value.
 func (n *Namespace) BindString(name string, ptr *string, def ...string) *Env {
-	e := n.new(name)
-	val, ok := os.LookupEnv(e.Name)
+	e := n.newText(name)
+	e.set = func(v string) { *ptr = v }
+	if len(def) > 0 {
+		e.def = def[0]
+	}
+	val, ok := n.resolve(e)
 	if ok {
 		e.Value = val
 		goto BIND
 	}
 	if len(def) > 0 {
-		e.Value = def[0]
+		e.Source = SourceDefault
+		e.Value = e.def
 	}
 
 BIND:
@@ -35,45 +272,91 @@ BIND:
 	return e
 }
 
-// BindInt binds integer into ptr with a optional default value.
-func (n *Namespace) BindInt(name string, ptr *int64, def ...int64) *Env {
-	e := n.new(name)
-	val, ok := os.LookupEnv(e.Name)
+// BindStringOptional binds a pointer to string into ptr, which is nil if the
+// variable is unset, so that an empty value can be told apart from an absent
+// one.
+func (n *Namespace) BindStringOptional(name string, ptr **string) *Env {
+	e := n.newText(name)
+	val, ok := n.resolve(e)
+	if !ok {
+		*ptr = nil
+		return e
+	}
+	e.Value = val
+	*ptr = &val
+	return e
+}
+
+// BindStringAliases binds string into ptr with a optional default value, like
+// BindString. If the variable is unset, the deprecated aliases are looked up
+// in order, and the first one set is used with a warning naming both it and
+// the preferred variable.
+func (n *Namespace) BindStringAliases(name string, aliases []string, ptr *string, def ...string) *Env {
+	e := n.newText(name)
+	e.set = func(v string) { *ptr = v }
+	if len(def) > 0 {
+		e.def = def[0]
+	}
+	val, ok := n.resolve(e)
 	if ok {
 		e.Value = val
 		goto BIND
 	}
+	for _, alias := range aliases {
+		key := n.key(alias)
+		if val, ok = n.lookupEnv(key); ok {
+			if n.warn != nil {
+				n.warn(key + " is deprecated, use " + e.Name + " instead")
+			}
+			e.Source = SourceEnv
+			e.Value = val
+			goto BIND
+		}
+	}
 	if len(def) > 0 {
-		e.Value = strconv.FormatInt(def[0], 10)
+		e.Source = SourceDefault
+		e.Value = e.def
 	}
 
 BIND:
-	i, err := strconv.ParseInt(e.Value, 10, 64)
-	if err != nil {
-		if len(def) > 0 {
-			*ptr = def[0]
-		}
-		return e
-	}
-	*ptr = i
+	*ptr = e.Value
 	return e
 }
 
+// BindInt binds integer into ptr with a optional default value.
+func (n *Namespace) BindInt(name string, ptr *int64, def ...int64) *Env {
+	return Bind(n, name, ptr, def...)
+}
+
 // BindUint binds unassigned integer into ptr with a optional default value.
 func (n *Namespace) BindUint(name string, ptr *uint64, def ...uint64) *Env {
-	e := n.new(name)
-	val, ok := os.LookupEnv(e.Name)
+	return Bind(n, name, ptr, def...)
+}
+
+// BindIntAuto binds integer into ptr with a optional default value. Unlike
+// BindInt, the base is implied by the prefix of value: "0x" for hexadecimal,
+// "0o" or "0" for octal, "0b" for binary, and decimal otherwise.
+func (n *Namespace) BindIntAuto(name string, ptr *int64, def ...int64) *Env {
+	e := n.new(name, "int64")
+	if len(def) > 0 {
+		e.def = strconv.FormatInt(def[0], 10)
+	}
+	val, ok := n.resolve(e)
 	if ok {
 		e.Value = val
 		goto BIND
 	}
 	if len(def) > 0 {
-		e.Value = strconv.FormatUint(def[0], 10)
+		e.Source = SourceDefault
+		e.Value = e.def
 	}
 
 BIND:
-	i, err := strconv.ParseUint(e.Value, 10, 64)
+	i, err := strconv.ParseInt(strings.TrimSpace(e.Value), 0, 64)
 	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
 		if len(def) > 0 {
 			*ptr = def[0]
 		}
@@ -83,21 +366,29 @@ BIND:
 	return e
 }
 
-// BindFloat binds float into ptr with a optional default value.
-func (n *Namespace) BindFloat(name string, ptr *float64, def ...float64) *Env {
-	e := n.new(name)
-	val, ok := os.LookupEnv(e.Name)
+// BindUintAuto binds unassigned integer into ptr with a optional default
+// value. The base is implied by the prefix of value as in BindIntAuto.
+func (n *Namespace) BindUintAuto(name string, ptr *uint64, def ...uint64) *Env {
+	e := n.new(name, "uint64")
+	if len(def) > 0 {
+		e.def = strconv.FormatUint(def[0], 10)
+	}
+	val, ok := n.resolve(e)
 	if ok {
 		e.Value = val
 		goto BIND
 	}
 	if len(def) > 0 {
-		e.Value = strconv.FormatFloat(def[0], 'f', -1, 64)
+		e.Source = SourceDefault
+		e.Value = e.def
 	}
 
 BIND:
-	i, err := strconv.ParseFloat(e.Value, 64)
+	i, err := strconv.ParseUint(strings.TrimSpace(e.Value), 0, 64)
 	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
 		if len(def) > 0 {
 			*ptr = def[0]
 		}
@@ -107,47 +398,95 @@ BIND:
 	return e
 }
 
+// BindFloat binds float into ptr with a optional default value.
+func (n *Namespace) BindFloat(name string, ptr *float64, def ...float64) *Env {
+	return Bind(n, name, ptr, def...)
+}
+
 // BindBool binds boolean into ptr with a optional default value.
 func (n *Namespace) BindBool(name string, ptr *bool, def ...bool) *Env {
-	e := n.new(name)
-	val, ok := os.LookupEnv(e.Name)
+	e := n.new(name, "bool")
+	if len(def) > 0 {
+		e.def = strconv.FormatBool(def[0])
+	}
+	val, ok := n.resolve(e)
 	if ok {
 		e.Value = val
 		goto BIND
 	}
 	if len(def) > 0 {
-		e.Value = strconv.FormatBool(def[0])
+		e.Source = SourceDefault
+		e.Value = e.def
 	}
 
 BIND:
-	switch strings.ToLower(strings.TrimSpace(e.Value)) {
-	case "1", "true":
-		*ptr = true
-	case "0", "false":
-		*ptr = false
-	default:
+	v, err := parseBool(e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
 		if len(def) > 0 {
 			*ptr = def[0]
 		}
+		return e
 	}
+	*ptr = v
 	return e
 }
 
+// BindBoolOptional binds a pointer to boolean into ptr, which is nil if the
+// variable is unset or malformed, for flags which are either explicitly true,
+// explicitly false, or inherited.
+func (n *Namespace) BindBoolOptional(name string, ptr **bool) *Env {
+	e := n.new(name, "bool")
+	val, ok := n.resolve(e)
+	if !ok {
+		*ptr = nil
+		return e
+	}
+	e.Value = val
+	v, err := parseBool(val)
+	if err != nil {
+		n.invalid(e, err)
+		*ptr = nil
+		return e
+	}
+	*ptr = &v
+	return e
+}
+
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "true":
+		return true, nil
+	case "0", "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid boolean %q", s)
+}
+
 // BindBool binds net.IP into ptr with a optional default value.
 func (n *Namespace) BindIP(name string, ptr *net.IP, def ...net.IP) *Env {
-	e := n.new(name)
-	val, ok := os.LookupEnv(e.Name)
+	e := n.new(name, "ip")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
 	if ok {
 		e.Value = val
 		goto BIND
 	}
 	if len(def) > 0 {
-		e.Value = def[0].String()
+		e.Source = SourceDefault
+		e.Value = e.def
 	}
 
 BIND:
 	v := net.ParseIP(strings.TrimSpace(e.Value))
 	if v == nil {
+		if ok {
+			n.invalid(e, &net.ParseError{Type: "IP address", Text: e.Value})
+		}
 		if len(def) > 0 {
 			*ptr = def[0]
 		}
@@ -159,19 +498,26 @@ BIND:
 
 // BindIPNet binds net.IPNet into ptr with a optional default value.
 func (n *Namespace) BindIPNet(name string, ptr *net.IPNet, def ...net.IPNet) *Env {
-	e := n.new(name)
-	val, ok := os.LookupEnv(e.Name)
+	e := n.new(name, "ipnet")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
 	if ok {
 		e.Value = val
 		goto BIND
 	}
 	if len(def) > 0 {
-		e.Value = def[0].String()
+		e.Source = SourceDefault
+		e.Value = e.def
 	}
 
 BIND:
 	_, v, err := net.ParseCIDR(strings.TrimSpace(e.Value))
 	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
 		if len(def) > 0 {
 			*ptr = def[0]
 		}
@@ -183,19 +529,26 @@ BIND:
 
 // BindTime binds time.Time into ptr with a optional default value.
 func (n *Namespace) BindTime(name string, ptr *time.Time, def ...time.Time) *Env {
-	e := n.new(name)
-	val, ok := os.LookupEnv(e.Name)
+	e := n.new(name, "time")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
 	if ok {
 		e.Value = val
 		goto BIND
 	}
 	if len(def) > 0 {
-		e.Value = def[0].String()
+		e.Source = SourceDefault
+		e.Value = e.def
 	}
 
 BIND:
 	v, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(e.Value))
 	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
 		if len(def) > 0 {
 			*ptr = def[0]
 		}
@@ -207,19 +560,26 @@ BIND:
 
 // BindDuration binds time.Duration into ptr with a optional default value.
 func (n *Namespace) BindDuration(name string, ptr *time.Duration, def ...time.Duration) *Env {
-	e := n.new(name)
-	val, ok := os.LookupEnv(e.Name)
+	e := n.new(name, "duration")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
 	if ok {
 		e.Value = val
 		goto BIND
 	}
 	if len(def) > 0 {
-		e.Value = def[0].String()
+		e.Source = SourceDefault
+		e.Value = e.def
 	}
 
 BIND:
 	v, err := time.ParseDuration(strings.TrimSpace(e.Value))
 	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
 		if len(def) > 0 {
 			*ptr = def[0]
 		}
@@ -231,15 +591,108 @@ BIND:
 
 // BindFunc binds value with given fn.
 func (n *Namespace) BindFunc(name string, fn EnvBindFunc) *Env {
-	e := n.new(name)
-	val, ok := os.LookupEnv(e.Name)
+	e := n.newText(name)
+	val, ok := n.resolve(e)
 	e.Value = fn(val, ok)
 	return e
 }
 
-// NewNamespace defines a new namespace of environment variable.
+// BindStringFunc binds the string returned by fn into ptr. Unlike BindFunc, fn
+// may reject the value by returning an error, in which case ptr is left
+// untouched, and the error is both recorded in the returned Env and returned.
+func (n *Namespace) BindStringFunc(name string, ptr *string, fn func(raw string, exists bool) (string, error)) (*Env, error) {
+	e := n.newText(name)
+	val, ok := n.resolve(e)
+	e.Value = val
+	v, err := fn(val, ok)
+	if err != nil {
+		n.invalid(e, err)
+		return e, err
+	}
+	*ptr = v
+	return e, nil
+}
+
+// Environ returns every variable in the environment under n, in the form of
+// "KEY=VALUE", which suits exec.Cmd.Env.
+func (n *Namespace) Environ() []string {
+	var v []string
+	prefix := keyPrefix(n.s)
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, prefix) {
+			v = append(v, kv)
+		}
+	}
+	return v
+}
+
+// Map returns every variable in the environment under n, keyed by name.
+func (n *Namespace) Map() map[string]string {
+	m := make(map[string]string)
+	for _, kv := range n.Environ() {
+		if i := strings.IndexByte(kv, '='); i >= 0 {
+			m[kv[:i]] = kv[i+1:]
+		}
+	}
+	return m
+}
+
+// Range calls fn for every variable in the environment under n, with the
+// lowercased remainder of its name after the prefix, and its value.
+func (n *Namespace) Range(fn func(suffix, value string)) {
+	prefix := keyPrefix(n.s)
+	for _, kv := range n.Environ() {
+		if i := strings.IndexByte(kv, '='); i >= 0 {
+			fn(strings.ToLower(kv[len(prefix):i]), kv[i+1:])
+		}
+	}
+}
+
+// NewNamespace defines a new namespace of environment variable. An empty s
+// defines the root namespace, whose variables are not prefixed.
 func NewNamespace(s string) *Namespace {
-	return &Namespace{strings.ToUpper(strings.ReplaceAll(s, " ", "_"))}
+	return NewNamespaceWithLookup(s, nil)
+}
+
+// RootNamespace defines the root namespace, whose variables are not
+// prefixed, for reading well-known variables such as PATH or NO_PROXY.
+func RootNamespace() *Namespace {
+	return NewNamespace("")
+}
+
+// NewNamespaceWithLookup defines a new namespace of variable, which retrieves
+// values with lookup rather than from the environment. A nil lookup defaults
+// to os.LookupEnv. As lookup cannot list its variables, Registry.Unused
+// reports none unless lookup is nil.
+func NewNamespaceWithLookup(s string, lookup LookupFunc) *Namespace {
+	var keys func() []string
+	if lookup != nil {
+		keys = func() []string { return nil }
+	}
+	return newNamespace(s, lookup, keys)
+}
+
+// NewNamespaceWithEnvs defines a new namespace of variable, which retrieves
+// values from envs, such as those returned by ParseEnv, as by LookupEnvs.
+// Unlike with NewNamespaceWithLookup, Registry.Unused reports the variables
+// of envs which are never looked up.
+func NewNamespaceWithEnvs(s string, envs []*Env) *Namespace {
+	return newNamespace(s, LookupEnvs(envs), func() []string {
+		keys := make([]string, len(envs))
+		for i, e := range envs {
+			keys[i] = e.Name
+		}
+		return keys
+	})
+}
+
+func newNamespace(s string, lookup LookupFunc, keys func() []string) *Namespace {
+	s = normalizeKey(s)
+	return &Namespace{
+		s:      s,
+		r:      &Registry{prefix: s, keys: keys},
+		lookup: lookup,
+	}
 }
 
 // EnvBindFunc is a function for binding value into variables. Applied value
@@ -0,0 +1,70 @@
+package envutil
+
+import (
+	"crypto/x509"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestStrictEmpty(t *testing.T) {
+	n := NewNamespaceWithLookup("app", mapLookup(map[string]string{
+		"APP_PROXY": "",
+		"APP_CA":    "",
+		"APP_PORT":  "",
+	})).TreatEmptyAsUnset(true).Strict(true)
+	var u *url.URL
+	var pool *x509.CertPool
+	var port int64
+	n.BindProxyURL("proxy", &u)
+	n.BindCertPool("ca", &pool)
+	n.BindInt("port", &port, 80)
+	err := n.Err()
+	if err == nil {
+		t.Fatal("Err() = nil, want error for APP_PORT")
+	}
+	if got, want := err.Error(), `APP_PORT="": invalid int64: empty value`; got != want {
+		t.Errorf("Err() = %q, want %q", got, want)
+	}
+}
+
+func TestKeyNormalization(t *testing.T) {
+	tests := []struct {
+		ns, name, want string
+	}{
+		{"app", "  db   host ", "APP_DB_HOST"},
+		{"app", "db_ _host", "APP_DB_HOST"},
+		{"app", "__db__host__", "APP_DB_HOST"},
+		{" my _app ", "db host", "MY_APP_DB_HOST"},
+		{"", "db host", "DB_HOST"},
+	}
+	for _, tt := range tests {
+		n := NewNamespaceWithLookup(tt.ns, mapLookup(nil))
+		if got := n.key(tt.name); got != tt.want {
+			t.Errorf("key(%q) in namespace %q = %q, want %q", tt.name, tt.ns, got, tt.want)
+		}
+	}
+}
+
+func TestOnErrorValidators(t *testing.T) {
+	var got []string
+	n := NewNamespaceWithLookup("app", mapLookup(map[string]string{
+		"APP_MODE": "fast",
+		"APP_NAME": "x",
+	})).OnError(func(e *Env, err error) {
+		got = append(got, err.Error())
+	})
+	var mode, name string
+	n.BindString("mode", &mode, "slow").OneOf("slow", "safe")
+	n.BindString("name", &name).Validate(func(string) error {
+		return errors.New("too short")
+	})
+	want := []string{
+		`APP_MODE="fast": invalid string: "fast" is not one of slow, safe`,
+		`APP_NAME="x": invalid string: too short`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("OnError got %q, want %q", got, want)
+	}
+}
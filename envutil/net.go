@@ -0,0 +1,372 @@
+package envutil
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// BindListenAddr binds a listen address, such as ":8080", "localhost:8080",
+// "0.0.0.0:8080" or "[::1]:8080", into ptr with a optional default value.
+// Invalid addresses fall back to the default, and the reason is recorded in
+// Err of the returned Env.
+func (n *Namespace) BindListenAddr(name string, ptr *string, def ...string) *Env {
+	return n.bindListenAddr(name, false, ptr, def...)
+}
+
+// BindListenAddrProbe is like BindListenAddr, but also probes the address by
+// listening on it over TCP and closing immediately, so that addresses which
+// are in use or not permitted are rejected at bind time.
+func (n *Namespace) BindListenAddrProbe(name string, ptr *string, def ...string) *Env {
+	return n.bindListenAddr(name, true, ptr, def...)
+}
+
+func (n *Namespace) bindListenAddr(name string, probe bool, ptr *string, def ...string) *Env {
+	e := n.new(name, "listen address")
+	if len(def) > 0 {
+		e.def = def[0]
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v := strings.TrimSpace(e.Value)
+	err := validateListenAddr(v)
+	if err == nil && probe {
+		var l net.Listener
+		if l, err = net.Listen("tcp", v); err == nil {
+			err = l.Close()
+		}
+	}
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// BindIPv4 binds net.IP into ptr with a optional default value, like BindIP,
+// but only accepts IPv4 addresses, including IPv4-mapped IPv6 ones such as
+// "::ffff:1.2.3.4".
+func (n *Namespace) BindIPv4(name string, ptr *net.IP, def ...net.IP) *Env {
+	return n.bindIPFamily(name, true, ptr, def...)
+}
+
+// BindIPv6 binds net.IP into ptr with a optional default value, like BindIP,
+// but only accepts IPv6 addresses. IPv4-mapped IPv6 addresses are rejected.
+func (n *Namespace) BindIPv6(name string, ptr *net.IP, def ...net.IP) *Env {
+	return n.bindIPFamily(name, false, ptr, def...)
+}
+
+func (n *Namespace) bindIPFamily(name string, v4 bool, ptr *net.IP, def ...net.IP) *Env {
+	e := n.new(name, "ip")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := parseIPFamily(strings.TrimSpace(e.Value), v4)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+func parseIPFamily(s string, v4 bool) (net.IP, error) {
+	v := net.ParseIP(s)
+	if v == nil {
+		return nil, fmt.Errorf("invalid IP %q", s)
+	}
+	if is4 := v.To4() != nil; is4 != v4 {
+		if v4 {
+			return nil, fmt.Errorf("%q is not an IPv4 address", s)
+		}
+		return nil, fmt.Errorf("%q is not an IPv6 address", s)
+	}
+	return v, nil
+}
+
+// BindNetipAddr binds netip.Addr into ptr with a optional default value.
+func (n *Namespace) BindNetipAddr(name string, ptr *netip.Addr, def ...netip.Addr) *Env {
+	e := n.new(name, "netip.Addr")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := netip.ParseAddr(strings.TrimSpace(e.Value))
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// BindNetipPrefix binds netip.Prefix into ptr with a optional default value.
+func (n *Namespace) BindNetipPrefix(name string, ptr *netip.Prefix, def ...netip.Prefix) *Env {
+	e := n.new(name, "netip.Prefix")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := netip.ParsePrefix(strings.TrimSpace(e.Value))
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// BindAddrPort binds netip.AddrPort, such as "127.0.0.1:8080" or
+// "[::1]:8080", into ptr with a optional default value.
+func (n *Namespace) BindAddrPort(name string, ptr *netip.AddrPort, def ...netip.AddrPort) *Env {
+	e := n.new(name, "netip.AddrPort")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := netip.ParseAddrPort(strings.TrimSpace(e.Value))
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// BindPortRange binds a range of ports, such as "8000-8100", into loPtr and
+// hiPtr with a optional default value. A single port is a range of itself.
+// Both bounds must be between 1 and 65535. Both pointers are either bound
+// together or left untouched. Whenever the default is used, Value of the
+// returned Env is in the canonical "low-high" form.
+func (n *Namespace) BindPortRange(name string, loPtr, hiPtr *uint16, def ...[2]uint16) *Env {
+	e := n.new(name, "port range")
+	if len(def) > 0 {
+		e.def = formatPortRange(def[0])
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	lo, hi, err := parsePortRange(e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*loPtr, *hiPtr = def[0][0], def[0][1]
+			e.Value = formatPortRange(def[0])
+		}
+		return e
+	}
+	*loPtr, *hiPtr = lo, hi
+	return e
+}
+
+func parsePortRange(s string) (lo, hi uint16, err error) {
+	s = strings.TrimSpace(s)
+	los, his := s, s
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		los, his = s[:i], s[i+1:]
+	}
+	l, err := strconv.ParseUint(strings.TrimSpace(los), 10, 16)
+	if err != nil {
+		return 0, 0, err
+	}
+	h, err := strconv.ParseUint(strings.TrimSpace(his), 10, 16)
+	if err != nil {
+		return 0, 0, err
+	}
+	if l == 0 || l > h {
+		return 0, 0, fmt.Errorf("invalid port range %d-%d", l, h)
+	}
+	return uint16(l), uint16(h), nil
+}
+
+func formatPortRange(r [2]uint16) string {
+	return strconv.FormatUint(uint64(r[0]), 10) + "-" + strconv.FormatUint(uint64(r[1]), 10)
+}
+
+// BindProxyURL binds a proxy URL into ptr with a optional default value. Only
+// the http, https, socks5 and socks5h schemes are accepted, and the URL must
+// not carry a path or fragment. An empty value means no proxy, and binds nil.
+// If the URL carries credentials, the returned Env is secret, and any password
+// in it is masked when the Env or its Err is rendered.
+func (n *Namespace) BindProxyURL(name string, ptr **url.URL, def ...*url.URL) *Env {
+	e := n.new(name, "proxy URL")
+	e.text = true
+	if len(def) > 0 && def[0] != nil {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 && def[0] != nil {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	s := strings.TrimSpace(e.Value)
+	// Credentials are masked even if the URL is rejected, as the value is
+	// then quoted in Err.
+	if u, err := url.Parse(s); err == nil && u.User != nil {
+		e.Secret = true
+		e.redacted = redactURL(u)
+	} else if err != nil && strings.Contains(s, "@") {
+		e.Secret = true
+	}
+	v, err := parseProxyURL(s)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+func parseProxyURL(s string) (*url.URL, error) {
+	if s == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.New("missing host in proxy URL")
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return nil, errors.New("proxy URL must not have a path, query or fragment")
+	}
+	return u, nil
+}
+
+func validateListenAddr(s string) error {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return err
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return fmt.Errorf("invalid port %q in address %q", port, s)
+	}
+	if looksLikeIP(host) && net.ParseIP(host) == nil {
+		return fmt.Errorf("invalid IP %q in address %q", host, s)
+	}
+	return nil
+}
+
+// looksLikeIP reports whether s is meant to be an IP literal rather than a
+// host name, that is, it contains a colon or consists of digits and dots.
+func looksLikeIP(s string) bool {
+	if s == "" {
+		return false
+	}
+	if strings.Contains(s, ":") {
+		return true
+	}
+	for _, c := range s {
+		if c != '.' && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,383 @@
+package envutil
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// BindIntRange binds a range of integers, such as "100-199" or "-10..-5",
+// into loPtr and hiPtr with a optional default value. A single integer is a
+// range of itself. Both pointers are either bound together or left untouched.
+func (n *Namespace) BindIntRange(name string, loPtr, hiPtr *int64, def ...[2]int64) *Env {
+	e := n.new(name, "integer range")
+	if len(def) > 0 {
+		e.def = strconv.FormatInt(def[0][0], 10) + "-" + strconv.FormatInt(def[0][1], 10)
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	lo, hi, err := parseIntRange(e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*loPtr, *hiPtr = def[0][0], def[0][1]
+		}
+		return e
+	}
+	*loPtr, *hiPtr = lo, hi
+	return e
+}
+
+// BindIntInRange binds integer between min and max inclusive into ptr with a
+// optional default value. Values out of range are rejected like malformed
+// ones. It panics if the default is out of range.
+func (n *Namespace) BindIntInRange(name string, ptr *int64, min, max int64, def ...int64) *Env {
+	return bindInRange(n, name, ptr, min, max, def...)
+}
+
+// BindUintInRange is like BindIntInRange, but binds unassigned integer.
+func (n *Namespace) BindUintInRange(name string, ptr *uint64, min, max uint64, def ...uint64) *Env {
+	return bindInRange(n, name, ptr, min, max, def...)
+}
+
+// BindFloatInRange is like BindIntInRange, but binds float.
+func (n *Namespace) BindFloatInRange(name string, ptr *float64, min, max float64, def ...float64) *Env {
+	return bindInRange(n, name, ptr, min, max, def...)
+}
+
+func bindInRange[T Integer | Float](n *Namespace, name string, ptr *T, min, max T, def ...T) *Env {
+	e := n.new(name, typeKind(reflect.TypeOf(ptr)))
+	if len(def) > 0 {
+		if !(def[0] >= min && def[0] <= max) {
+			panic(fmt.Sprintf("envutil: default %v of %s out of range [%v, %v]", def[0], e.Name, min, max))
+		}
+		e.def = formatNumber(def[0])
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := parseNumber[T](e.Value)
+	// Compared so that NaN, which is unordered, is out of range as well.
+	if err == nil && !(v >= min && v <= max) {
+		err = fmt.Errorf("%v out of range [%v, %v]", v, min, max)
+	}
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// BindPercent binds a percentage into ptr as a fraction between 0 and 1, with
+// a optional default value. The value is normalized as follows:
+//
+//   - a trailing "%" divides the number by 100, so "85%" and "1%" are 0.85
+//     and 0.01;
+//   - a number without "%" not greater than 1 is a fraction already, so
+//     "0.85" is 0.85 and "1" is 1 (that is, 100%);
+//   - a number without "%" greater than 1 is a percentage, so "85" is 0.85.
+//
+// Hence a bare "5" means 5%, not 500%, while a bare "1" means 100%, not 1%.
+// Operators should append "%" whenever a value is meant as a percentage, so
+// that it is never ambiguous. Results outside of [0, 1] fall back to the
+// default.
+func (n *Namespace) BindPercent(name string, ptr *float64, def ...float64) *Env {
+	e := n.new(name, "percentage")
+	if len(def) > 0 {
+		e.def = strconv.FormatFloat(def[0], 'f', -1, 64)
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := parsePercent(e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+func parsePercent(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	pct := strings.HasSuffix(s, "%")
+	v, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, "%")), 64)
+	if err != nil {
+		return 0, err
+	}
+	if pct || v > 1 {
+		v /= 100
+	}
+	if math.IsNaN(v) || v < 0 || v > 1 {
+		return 0, fmt.Errorf("percentage %q out of range", s)
+	}
+	return v, nil
+}
+
+// BindRandomSeed binds a seed into ptr with a optional default value. The
+// value is either an integer, or "random" or "auto" for a seed generated by
+// crypto/rand. The seed in effect is written back into Value of the returned
+// Env, so that a run can be reproduced from logs.
+func (n *Namespace) BindRandomSeed(name string, ptr *int64, def ...int64) *Env {
+	e := n.new(name, "random seed")
+	if len(def) > 0 {
+		e.def = strconv.FormatInt(def[0], 10)
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := parseRandomSeed(e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+			e.Value = strconv.FormatInt(def[0], 10)
+		}
+		return e
+	}
+	*ptr = v
+	e.Value = strconv.FormatInt(v, 10)
+	return e
+}
+
+func parseRandomSeed(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "random", "auto":
+		i, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+		if err != nil {
+			return 0, err
+		}
+		return i.Int64(), nil
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// BindCount binds a count into ptr with a optional default value, given
+// either as a repetition of 'v', such as "vvv", or as a plain integer, so that
+// "vvv" and "3" both bind 3.
+func (n *Namespace) BindCount(name string, ptr *int, def ...int) *Env {
+	return n.BindCountRune(name, 'v', ptr, def...)
+}
+
+// BindCountRune is like BindCount, but counts repetitions of c instead.
+func (n *Namespace) BindCountRune(name string, c rune, ptr *int, def ...int) *Env {
+	e := n.new(name, "count")
+	if len(def) > 0 {
+		e.def = strconv.Itoa(def[0])
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := parseCount(e.Value, c)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+func parseCount(s string, c rune) (int, error) {
+	s = strings.TrimSpace(s)
+	if s != "" && strings.Trim(s, string(c)) == "" {
+		return strings.Count(s, string(c)), nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 {
+		return 0, fmt.Errorf("negative count %d", v)
+	}
+	return v, nil
+}
+
+// BindBigInt binds an arbitrary-precision integer into ptr with a optional
+// default value. The base is implied by the prefix of value as in BindIntAuto.
+func (n *Namespace) BindBigInt(name string, ptr **big.Int, def ...*big.Int) *Env {
+	e := n.new(name, "big.Int")
+	if len(def) > 0 && def[0] != nil {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 && def[0] != nil {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, valid := new(big.Int).SetString(strings.TrimSpace(e.Value), 0)
+	if !valid {
+		if ok {
+			n.invalid(e, fmt.Errorf("invalid integer %q", e.Value))
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// BindBigFloat binds an arbitrary-precision float of precision prec into ptr
+// with a optional default value. A zero prec means 64. Value of the returned
+// Env keeps the text as given, so that no precision is lost in logs.
+func (n *Namespace) BindBigFloat(name string, prec uint, ptr **big.Float, def ...*big.Float) *Env {
+	e := n.new(name, "big.Float")
+	if len(def) > 0 && def[0] != nil {
+		e.def = def[0].Text('g', -1)
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 && def[0] != nil {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, valid := new(big.Float).SetPrec(prec).SetString(strings.TrimSpace(e.Value))
+	if !valid {
+		if ok {
+			n.invalid(e, fmt.Errorf("invalid float %q", e.Value))
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// BindBigRat binds an exact rational number, such as "0.0025" or "1/400",
+// into ptr with a optional default value.
+func (n *Namespace) BindBigRat(name string, ptr **big.Rat, def ...*big.Rat) *Env {
+	e := n.new(name, "big.Rat")
+	if len(def) > 0 && def[0] != nil {
+		e.def = def[0].RatString()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 && def[0] != nil {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, valid := new(big.Rat).SetString(strings.TrimSpace(e.Value))
+	if !valid {
+		if ok {
+			n.invalid(e, fmt.Errorf("invalid rational %q", e.Value))
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// parseIntRange parses "lo-hi", "lo..hi" or a single integer. A hyphen only
+// separates the bounds if it follows a digit, so that negative bounds work.
+func parseIntRange(s string) (lo, hi int64, err error) {
+	s = strings.TrimSpace(s)
+	los, his := s, s
+	if i := strings.Index(s, ".."); i >= 0 {
+		los, his = s[:i], s[i+2:]
+	} else {
+		for i := 1; i < len(s); i++ {
+			if s[i] == '-' && (s[i-1] >= '0' && s[i-1] <= '9' || s[i-1] == ' ') {
+				los, his = s[:i], s[i+1:]
+				break
+			}
+		}
+	}
+	if lo, err = strconv.ParseInt(strings.TrimSpace(los), 10, 64); err != nil {
+		return 0, 0, err
+	}
+	if hi, err = strconv.ParseInt(strings.TrimSpace(his), 10, 64); err != nil {
+		return 0, 0, err
+	}
+	if lo > hi {
+		return 0, 0, fmt.Errorf("invalid range %d-%d: low bound exceeds high bound", lo, hi)
+	}
+	return lo, hi, nil
+}
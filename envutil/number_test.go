@@ -0,0 +1,46 @@
+package envutil
+
+import "testing"
+
+func TestParsePercent(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		err  bool
+	}{
+		{in: "1", want: 1},
+		{in: "1%", want: 0.01},
+		{in: "0.85", want: 0.85},
+		{in: "85", want: 0.85},
+		{in: " 85 % ", want: 0.85},
+		{in: "100%", want: 1},
+		{in: "0", want: 0},
+		{in: "101", err: true},
+		{in: "-1%", err: true},
+		{in: "NaN", err: true},
+		{in: "NaN%", err: true},
+		{in: "Inf", err: true},
+		{in: "", err: true},
+	}
+	for _, tt := range tests {
+		got, err := parsePercent(tt.in)
+		if tt.err {
+			if err == nil {
+				t.Errorf("parsePercent(%q) = %v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parsePercent(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestBindFloatInRangeNaN(t *testing.T) {
+	n := NewNamespaceWithLookup("app", mapLookup(map[string]string{"APP_RATIO": "NaN"}))
+	var v float64
+	e := n.BindFloatInRange("ratio", &v, 0, 1, 0.5)
+	if e.Err == nil || v != 0.5 {
+		t.Errorf("BindFloatInRange with NaN: v = %v, Err = %v; want 0.5 and error", v, e.Err)
+	}
+}
@@ -0,0 +1,84 @@
+package envutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rate is a number of events allowed per interval.
+type Rate struct {
+	Events int64
+	Per    time.Duration
+}
+
+func (r Rate) String() string {
+	return strconv.FormatInt(r.Events, 10) + "/" + r.Per.String()
+}
+
+// EventsPerSecond returns r as a number of events per second, which is the
+// unit of rate.Limit in golang.org/x/time/rate.
+func (r Rate) EventsPerSecond() float64 {
+	return float64(r.Events) / r.Per.Seconds()
+}
+
+// BindRateLimit binds a rate in the form of "count/interval" into ptr with a
+// optional default value. The interval is either "s", "m" or "h", or a full
+// duration such as "500ms". A bare count means per second. Non-positive
+// counts or intervals fall back to the default.
+func (n *Namespace) BindRateLimit(name string, ptr *Rate, def ...Rate) *Env {
+	e := n.new(name, "rate")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := parseRate(e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+func parseRate(s string) (Rate, error) {
+	r := Rate{Per: time.Second}
+	s = strings.TrimSpace(s)
+	count, unit := s, ""
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		count, unit = strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+	}
+	var err error
+	if r.Events, err = strconv.ParseInt(count, 10, 64); err != nil {
+		return r, err
+	}
+	switch unit {
+	case "":
+	case "s", "m", "h":
+		r.Per, _ = time.ParseDuration("1" + unit)
+	default:
+		if r.Per, err = time.ParseDuration(unit); err != nil {
+			return r, err
+		}
+	}
+	if r.Events <= 0 || r.Per <= 0 {
+		return r, fmt.Errorf("rate %q must be positive", s)
+	}
+	return r, nil
+}
@@ -0,0 +1,234 @@
+package envutil
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Registry records every Env bound by a Namespace, in binding order. Binding
+// the same name again replaces the previous record.
+type Registry struct {
+	mu     sync.RWMutex
+	envs   []*Env
+	prefix string
+	used   map[string]bool
+	keys   func() []string // names of variables present, or nil for os.Environ
+}
+
+func (r *Registry) use(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.used == nil {
+		r.used = make(map[string]bool)
+	}
+	r.used[key] = true
+}
+
+// usedKeys returns the names of every variable looked up, including
+// deprecated aliases.
+func (r *Registry) usedKeys() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([]string, 0, len(r.used))
+	for key := range r.used {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (r *Registry) add(e *Env) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, v := range r.envs {
+		if v.Name == e.Name {
+			r.envs[i] = e
+			return
+		}
+	}
+	r.envs = append(r.envs, e)
+}
+
+// All returns all recorded Envs in binding order.
+func (r *Registry) All() []*Env {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]*Env(nil), r.envs...)
+}
+
+// Explain returns the explanation of every recorded Env, as by Env.Explain,
+// one per line in binding order.
+func (r *Registry) Explain() string {
+	var b strings.Builder
+	for _, e := range r.All() {
+		b.WriteString(e.Explain())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Lookup returns the Env recorded under the full variable name, or nil.
+func (r *Registry) Lookup(name string) *Env {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, v := range r.envs {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// Unused returns the sorted names of variables under the namespace prefix
+// which were never looked up by a binder, aliases included. Variables are
+// taken from the source of the namespace, which is the environment unless
+// the namespace is created by NewNamespaceWithEnvs or NewNamespaceWithLookup.
+// It is meant to be called after all bindings, to catch misspelled names.
+func (r *Registry) Unused() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var v []string
+	for _, key := range r.presentKeys() {
+		if !strings.HasPrefix(key, keyPrefix(r.prefix)) || r.used[key] {
+			continue
+		}
+		v = append(v, key)
+	}
+	sort.Strings(v)
+	return v
+}
+
+// presentKeys returns the names of the variables present in the source of
+// the namespace.
+func (r *Registry) presentKeys() []string {
+	if r.keys != nil {
+		return r.keys()
+	}
+	var keys []string
+	for _, kv := range os.Environ() {
+		if i := strings.IndexByte(kv, '='); i >= 0 {
+			keys = append(keys, kv[:i])
+		}
+	}
+	return keys
+}
+
+// VarSpec describes a variable bound by a Namespace, for documentation.
+type VarSpec struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Default  string   `json:"default,omitempty"`
+	Secret   bool     `json:"secret,omitempty"`
+	Options  []string `json:"options,omitempty"`
+}
+
+// Manifest returns the specs of all recorded Envs in binding order. Defaults
+// of secret variables are masked.
+func (r *Registry) Manifest() []VarSpec {
+	envs := r.All()
+	v := make([]VarSpec, len(envs))
+	for i, e := range envs {
+		v[i] = VarSpec{
+			Name:     e.Name,
+			Type:     e.kind,
+			Required: e.required,
+			Default:  e.def,
+			Secret:   e.Secret,
+			Options:  e.options,
+		}
+		if e.Secret {
+			v[i].Default = mask(e.def)
+		}
+	}
+	return v
+}
+
+// Equal reports whether r and other record equal Envs, as by Env.Equal,
+// regardless of order.
+func (r *Registry) Equal(other *Registry) bool {
+	a, b := r.All(), other.All()
+	if len(a) != len(b) {
+		return false
+	}
+	for _, e := range a {
+		if !e.Equal(other.Lookup(e.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// DiffKind describes how a variable differs between two registries.
+type DiffKind int
+
+// Kinds of EnvDiff.
+const (
+	DiffAdded DiffKind = iota + 1
+	DiffRemoved
+	DiffChanged
+)
+
+func (k DiffKind) String() string {
+	switch k {
+	case DiffAdded:
+		return "added"
+	case DiffRemoved:
+		return "removed"
+	case DiffChanged:
+		return "changed"
+	}
+	return "unknown"
+}
+
+// EnvDiff is a difference of a single variable between two registries. Old
+// and New are both "****" if either side is secret, so that neither the
+// length nor any character of a secret value is revealed.
+type EnvDiff struct {
+	Name string
+	Kind DiffKind
+	Old  string
+	New  string
+}
+
+// Diff compares the variables recorded in a and b, and returns their
+// differences sorted by name.
+func Diff(a, b *Registry) []EnvDiff {
+	olds := make(map[string]*Env)
+	for _, e := range a.All() {
+		olds[e.Name] = e
+	}
+	var diffs []EnvDiff
+	for _, e := range b.All() {
+		old, ok := olds[e.Name]
+		if !ok {
+			diffs = append(diffs, EnvDiff{Name: e.Name, Kind: DiffAdded, New: diffValue(e)})
+			continue
+		}
+		delete(olds, e.Name)
+		if old.Value == e.Value && old.Secret == e.Secret {
+			continue
+		}
+		d := EnvDiff{Name: e.Name, Kind: DiffChanged, Old: old.Value, New: e.Value}
+		if old.Secret || e.Secret {
+			d.Old, d.New = secretDiff, secretDiff
+		}
+		diffs = append(diffs, d)
+	}
+	for _, e := range olds {
+		diffs = append(diffs, EnvDiff{Name: e.Name, Kind: DiffRemoved, Old: diffValue(e)})
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Name < diffs[j].Name })
+	return diffs
+}
+
+// secretDiff replaces secret values in EnvDiff.
+const secretDiff = "****"
+
+func diffValue(e *Env) string {
+	if e.Secret {
+		return secretDiff
+	}
+	return e.Value
+}
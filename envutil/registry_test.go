@@ -0,0 +1,48 @@
+package envutil
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffSecret(t *testing.T) {
+	a := NewNamespaceWithLookup("app", mapLookup(map[string]string{"APP_DSN": "host=a password=hunter22", "APP_GONE": "supersecret"}))
+	b := NewNamespaceWithLookup("app", mapLookup(map[string]string{"APP_DSN": "host=b password=hunter22", "APP_NEW": "supersecret"}))
+	var s string
+	a.BindDSN("dsn", &s)
+	a.BindDSN("gone", &s)
+	b.BindDSN("dsn", &s)
+	b.BindDSN("new", &s)
+	diffs := Diff(a.Registry(), b.Registry())
+	if len(diffs) != 3 {
+		t.Fatalf("Diff = %v, want 3 differences", diffs)
+	}
+	for _, d := range diffs {
+		if (d.Old != "" && d.Old != "****") || (d.New != "" && d.New != "****") {
+			t.Errorf("Diff of secret %s: Old = %q, New = %q; want masked", d.Name, d.Old, d.New)
+		}
+	}
+}
+
+func TestUnusedSource(t *testing.T) {
+	t.Setenv("APP_STRAY", "1")
+	envs, err := ParseEnv(strings.NewReader("APP_HOST=db\nAPP_PORTT=5432\nOTHER=1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s string
+	n := NewNamespaceWithEnvs("app", envs)
+	n.BindString("host", &s)
+	if got, want := n.Registry().Unused(), []string{"APP_PORTT"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unused() with envs = %q, want %q", got, want)
+	}
+	n = NewNamespaceWithLookup("app", LookupEnvs(envs))
+	if got := n.Registry().Unused(); len(got) != 0 {
+		t.Errorf("Unused() with lookup = %q, want none", got)
+	}
+	n = NewNamespace("app")
+	if got, want := n.Registry().Unused(), []string{"APP_STRAY"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unused() with environment = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,113 @@
+package envutil
+
+import (
+	"errors"
+	"strings"
+)
+
+// BindShellWords binds the words of a shell-like command line, such as
+// `--foo "some value" --bar=1`, into ptr with a optional default value. The
+// value is split by SplitShellWords, and falls back to the default if it is
+// malformed.
+func (n *Namespace) BindShellWords(name string, ptr *[]string, def ...[]string) *Env {
+	e := n.new(name, "shell words")
+	if len(def) > 0 {
+		e.def = JoinShellWords(def[0])
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		*ptr = append([]string(nil), def[0]...)
+		e.Value = e.def
+		return e
+	}
+
+BIND:
+	v, err := SplitShellWords(e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = append([]string(nil), def[0]...)
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// SplitShellWords splits s into words like a POSIX shell, without performing
+// any expansion. Words are separated by unquoted white space. Within single
+// quotes every character is literal. Within double quotes a backslash only
+// escapes '"', '\\', '$', '`' and newline. Elsewhere a backslash escapes any
+// character. Unterminated quotes or a trailing backslash are errors.
+func SplitShellWords(s string) ([]string, error) {
+	var (
+		words  []string
+		word   strings.Builder
+		inWord bool
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+			continue
+		case c == '\\':
+			if i++; i >= len(s) {
+				return nil, errors.New("trailing backslash")
+			}
+			word.WriteByte(s[i])
+		case c == '\'':
+			j := strings.IndexByte(s[i+1:], '\'')
+			if j < 0 {
+				return nil, errors.New("unterminated single quote")
+			}
+			word.WriteString(s[i+1 : i+1+j])
+			i += j + 1
+		case c == '"':
+			for i++; ; i++ {
+				if i >= len(s) {
+					return nil, errors.New("unterminated double quote")
+				}
+				if s[i] == '"' {
+					break
+				}
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("\"\\$`\n", s[i+1]) >= 0 {
+					i++
+				}
+				word.WriteByte(s[i])
+			}
+		default:
+			word.WriteByte(c)
+		}
+		inWord = true
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// JoinShellWords joins words into a command line which SplitShellWords splits
+// back into the same words. Words are single quoted where necessary.
+func JoinShellWords(words []string) string {
+	ss := make([]string, len(words))
+	for i, w := range words {
+		if w != "" && strings.IndexAny(w, " \t\r\n'\"\\$`") < 0 {
+			ss[i] = w
+			continue
+		}
+		ss[i] = "'" + strings.ReplaceAll(w, "'", `'\''`) + "'"
+	}
+	return strings.Join(ss, " ")
+}
@@ -0,0 +1,40 @@
+package envutil
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// BindSlogLevel binds slog.Level into ptr with a optional default value. The
+// value is a level name such as "debug", "info", "warn" or "error",
+// case-insensitively, optionally with an offset such as "warn+2", as accepted
+// by slog.Level.UnmarshalText.
+func (n *Namespace) BindSlogLevel(name string, ptr *slog.Level, def ...slog.Level) *Env {
+	e := n.new(name, "slog.Level")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	var v slog.Level
+	if err := v.UnmarshalText([]byte(strings.TrimSpace(e.Value))); err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
@@ -0,0 +1,259 @@
+package envutil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// BindStruct binds every exported field of the struct pointed to by ptr, as by
+// BindAny. A field is bound to the variable named by its `env` tag, or else
+// to its name converted to upper snake case, such as MAX_CONNS for MaxConns.
+// Fields tagged `env:"-"` are skipped. The `default` tag gives the default
+// value in the same form as the variable, such as `default:"30s"` for a
+// time.Duration. Unlike a malformed variable, which falls back to the
+// default, a malformed default is a programming error, and is reported
+// without binding the field.
+//
+// Fields are converted as by BindAny, so types implementing
+// encoding.TextUnmarshaler, such as netip.Addr, are supported. So are slices
+// of, and maps from strings to, such types, whose elements are separated by
+// the `envSeparator` tag, or else by ",", and whose map entries are in the
+// form of "key=value". A malformed element is reported with its index.
+//
+// Fields of struct type, or pointer to struct type, that BindAny does not
+// support are bound recursively, with the names of their fields prefixed by
+// the `envPrefix` tag, or else by the field name in upper snake case. Under
+// namespace TURRET, field Host in field DB tagged `envPrefix:"DB"` is bound
+// to TURRET_DB_HOST. Embedded structs are flattened instead, without any
+// prefix unless tagged with `envPrefix`. Types which contain themselves
+// through pointers are rejected, and so are fields bound to the same variable
+// as another.
+//
+// Fields tagged `secret:"true"` are bound to secret Envs, whose values are
+// masked wherever they are rendered, including the returned error.
+//
+// Nil pointer fields are left nil unless configured, which tells "not
+// configured" apart from a zero value. A pointer to a struct is allocated
+// only if any variable of its fields is present, and a pointer to any other
+// type only if its variable is present and valid, or it has a default.
+//
+// Fields tagged `required:"true"` are reported if their variables are unset
+// or empty, by their full names, unless they are in a struct left nil. Such
+// fields may not have a default.
+//
+// Every field which fails to bind is reported in the returned error, which is
+// joined by errors.Join.
+func (n *Namespace) BindStruct(ptr any) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("envutil: BindStruct of non-struct pointer %T", ptr)
+	}
+	rv = rv.Elem()
+	b := &structBinder{n: n, seen: make(map[string]string)}
+	b.bind(rv, "", rv.Type().Name(), []reflect.Type{rv.Type()})
+	return errors.Join(b.errs...)
+}
+
+// ValidateStruct reports every problem BindStruct would report for ptr, but
+// binds into a new zero value of the type ptr points to, which is discarded.
+// Hence nothing but the registry of n is changed. Combined with a namespace
+// reading from a parsed file, this checks configuration before deployment:
+//
+//	envs, err := envutil.ParseEnv(f)
+//	...
+//	ns := envutil.NewNamespaceWithEnvs("app", envs)
+//	err = ns.ValidateStruct(&Config{})
+func (n *Namespace) ValidateStruct(ptr any) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("envutil: ValidateStruct of non-struct pointer %T", ptr)
+	}
+	return n.BindStruct(reflect.New(rv.Elem().Type()).Interface())
+}
+
+type structBinder struct {
+	n *Namespace
+	// seen maps names of variables bound to the paths of their fields.
+	seen map[string]string
+	errs []error
+}
+
+func (b *structBinder) errorf(format string, a ...any) {
+	b.errs = append(b.errs, fmt.Errorf(format, a...))
+}
+
+// bind binds the fields of struct rv, prefixing their variable names by
+// prefix, and reports whether any of their variables is present. Errors name
+// fields by their path from the root struct. Types are the struct types being
+// bound, from the root to rv, to detect cycles.
+func (b *structBinder) bind(rv reflect.Value, prefix, path string, types []reflect.Type) (present bool) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		tag := f.Tag.Get("env")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		fv := rv.Field(i)
+		fpath := f.Name
+		if path != "" {
+			fpath = path + "." + f.Name
+		}
+		if st := structType(f.Type); st != nil {
+			if containsType(types, st) {
+				b.errorf("envutil: field %s: cycle through type %v", fpath, st)
+				continue
+			}
+			p, ok := f.Tag.Lookup("envPrefix")
+			if !ok && !f.Anonymous {
+				p = snakeCase(f.Name)
+			}
+			types := append(types, st)
+			if f.Type.Kind() != reflect.Pointer || !fv.IsNil() {
+				present = b.bind(reflect.Indirect(fv), joinPrefix(prefix, p), fpath, types) || present
+				continue
+			}
+			// Required fields of a struct left nil are not missing, as
+			// the struct is not configured at all.
+			sub := reflect.New(st)
+			errs := b.errs
+			b.errs = nil
+			if b.bind(sub.Elem(), joinPrefix(prefix, p), fpath, types) {
+				fv.Set(sub)
+				present = true
+				errs = append(errs, b.errs...)
+			} else {
+				for _, err := range b.errs {
+					if !errors.Is(err, ErrRequired) {
+						errs = append(errs, err)
+					}
+				}
+			}
+			b.errs = errs
+			continue
+		}
+		if tag == "" {
+			tag = snakeCase(f.Name)
+		}
+		name := joinPrefix(prefix, tag)
+		key := b.n.key(name)
+		if prev, ok := b.seen[key]; ok {
+			b.errorf("envutil: fields %s and %s are both bound to %s", prev, fpath, key)
+			continue
+		}
+		b.seen[key] = fpath
+		// Pointers are bound through a scratch value, which is only
+		// assigned if the variable is present, or a default is given.
+		target, elem := fv, false
+		c, ok := fieldCodec(f.Type, f.Tag)
+		if !ok && f.Type.Kind() == reflect.Pointer {
+			c, ok = fieldCodec(f.Type.Elem(), f.Tag)
+			elem = true
+		}
+		if !ok {
+			b.errorf("envutil: field %s: no codec registered for %v", fpath, f.Type)
+			continue
+		}
+		if f.Type.Kind() == reflect.Pointer {
+			target = reflect.New(f.Type).Elem()
+			if elem {
+				target = reflect.New(f.Type.Elem()).Elem()
+			}
+		}
+		required := f.Tag.Get("required") == "true"
+		var def []any
+		if d, ok := f.Tag.Lookup("default"); ok {
+			if required {
+				b.errorf("envutil: field %s: both required and default", fpath)
+				continue
+			}
+			v, err := c.parse(d)
+			if err != nil {
+				b.errorf("envutil: field %s: invalid default %q: %w", fpath, d, err)
+				continue
+			}
+			def = append(def, v)
+		}
+		e := b.n.bindCodec(name, c, target, f.Tag.Get("secret") == "true", def...)
+		if e.Err != nil {
+			b.errorf("envutil: field %s: %w", fpath, e.Err)
+		} else if v, ok := b.n.lookupEnv(e.Name); required && (!ok || v == "") {
+			b.errorf("envutil: field %s: %w", fpath, &ParseError{Name: e.Name, Err: ErrRequired})
+		}
+		_, found := b.n.lookupEnv(e.Name)
+		present = present || found
+		if f.Type.Kind() == reflect.Pointer && ((found && e.Err == nil) || len(def) > 0) {
+			if elem {
+				target = target.Addr()
+			}
+			fv.Set(target)
+		}
+	}
+	return present
+}
+
+// fieldCodec returns the codec for a field of type t with the given tag.
+// Slices and maps are supported if their elements are, separated by the
+// `envSeparator` tag, or else by ",".
+func fieldCodec(t reflect.Type, tag reflect.StructTag) (codec, bool) {
+	if c, ok := codecFor(t); ok {
+		return c, true
+	}
+	sep := tag.Get("envSeparator")
+	if sep == "" {
+		sep = ","
+	}
+	return collectionCodec(t, sep)
+}
+
+// structType returns the struct type which t is, or points to, if it is to be
+// bound recursively rather than by a codec. Otherwise it returns nil.
+func structType(t reflect.Type) reflect.Type {
+	if _, ok := codecFor(t); ok {
+		return nil
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if _, ok := codecFor(t); ok || t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+func containsType(types []reflect.Type, t reflect.Type) bool {
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPrefix(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
+
+// snakeCase converts a Go identifier into upper snake case. A run of capitals
+// is kept as a single word, so HTTPTimeout becomes HTTP_TIMEOUT.
+func snakeCase(s string) string {
+	rs := []rune(s)
+	var b strings.Builder
+	for i, r := range rs {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := rs[i-1]
+			next := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && next) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package envutil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// DupBase is exported, so that its fields are promoted when embedded.
+type DupBase struct {
+	Host string
+	Port int
+}
+
+type testDupConfig struct {
+	DupBase
+	Host string
+}
+
+func TestBindStructDuplicateEmbedded(t *testing.T) {
+	n := NewNamespaceWithLookup("app", mapLookup(map[string]string{"APP_HOST": "db"}))
+	var c testDupConfig
+	err := n.BindStruct(&c)
+	if err == nil {
+		t.Fatal("BindStruct with an embedded field bound twice: err = nil")
+	}
+	want := "envutil: fields testDupConfig.DupBase.Host and testDupConfig.Host are both bound to APP_HOST"
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("BindStruct: err = %q, want %q", err, want)
+	}
+}
+
+type testTLS struct {
+	Cert string `required:"true"`
+}
+
+type testRequiredConfig struct {
+	Token string `required:"true"`
+	TLS   *testTLS
+}
+
+func TestBindStructRequired(t *testing.T) {
+	tests := []struct {
+		env     map[string]string
+		missing []string
+	}{
+		{map[string]string{"APP_TOKEN": "x"}, nil},
+		{map[string]string{"APP_TOKEN": ""}, []string{"APP_TOKEN"}},
+		{map[string]string{"APP_TOKEN": "x", "APP_TLS_CERT": ""}, []string{"APP_TLS_CERT"}},
+	}
+	for _, tt := range tests {
+		n := NewNamespaceWithLookup("app", mapLookup(tt.env))
+		var c testRequiredConfig
+		err := n.BindStruct(&c)
+		if len(tt.missing) == 0 {
+			if err != nil {
+				t.Errorf("BindStruct with %v: %v", tt.env, err)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrRequired) {
+			t.Errorf("BindStruct with %v: err = %v, want ErrRequired", tt.env, err)
+			continue
+		}
+		for _, name := range tt.missing {
+			if !strings.Contains(err.Error(), name+": required variable is not set") {
+				t.Errorf("BindStruct with %v: err = %q, want %s reported", tt.env, err, name)
+			}
+		}
+	}
+}
+
+func TestBindStructRequiredWithDefault(t *testing.T) {
+	var c struct {
+		Token string `required:"true" default:"x"`
+	}
+	n := NewNamespaceWithLookup("app", mapLookup(nil))
+	if err := n.BindStruct(&c); err == nil {
+		t.Error("BindStruct of a required field with a default: err = nil")
+	}
+}
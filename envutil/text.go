@@ -0,0 +1,231 @@
+package envutil
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// BindStringTrim binds string into ptr with a optional default value. Leading
+// and trailing white space is trimmed from both the value and the default.
+func (n *Namespace) BindStringTrim(name string, ptr *string, def ...string) *Env {
+	return n.bindStringTransform(name, strings.TrimSpace, ptr, def...)
+}
+
+// BindStringNormalize is like BindStringTrim, but also collapses every run of
+// internal white space into a single space.
+func (n *Namespace) BindStringNormalize(name string, ptr *string, def ...string) *Env {
+	return n.bindStringTransform(name, func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	}, ptr, def...)
+}
+
+// BindStringLower binds string into ptr with a optional default value, both
+// lowercased. Value of the returned Env keeps the original text.
+func (n *Namespace) BindStringLower(name string, ptr *string, def ...string) *Env {
+	return n.bindStringTransform(name, strings.ToLower, ptr, def...)
+}
+
+// BindStringUpper binds string into ptr with a optional default value, both
+// uppercased. Value of the returned Env keeps the original text.
+func (n *Namespace) BindStringUpper(name string, ptr *string, def ...string) *Env {
+	return n.bindStringTransform(name, strings.ToUpper, ptr, def...)
+}
+
+// BindStringUnquote binds string into ptr with a optional default value. If
+// the value is enclosed in matching double quotes or backquotes, it is
+// unquoted by strconv.Unquote, and if in single quotes, they are stripped. A
+// value which fails to unquote is bound verbatim.
+func (n *Namespace) BindStringUnquote(name string, ptr *string, def ...string) *Env {
+	return n.bindStringTransform(name, unquote, ptr, def...)
+}
+
+func unquote(s string) string {
+	if len(s) < 2 || s[0] != s[len(s)-1] {
+		return s
+	}
+	switch s[0] {
+	case '"', '`':
+		if v, err := strconv.Unquote(s); err == nil {
+			return v
+		}
+	case '\'':
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// BindStringTemplate binds the output of executing the value as a
+// text/template with data into ptr with a optional default value, which is a
+// template as well. If the value fails to parse or execute, the default is
+// bound instead, and the error is returned.
+func (n *Namespace) BindStringTemplate(name string, data any, ptr *string, def ...string) (*Env, error) {
+	e := n.newText(name)
+	if len(def) > 0 {
+		e.def = def[0]
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := executeTemplate(e.Name, e.Value, data)
+	if err != nil {
+		n.invalid(e, err)
+		if ok && len(def) > 0 {
+			if v, err := executeTemplate(e.Name, def[0], data); err == nil {
+				*ptr = v
+			}
+		}
+		return e, e.Err
+	}
+	*ptr = v
+	return e, nil
+}
+
+func executeTemplate(name, text string, data any) (string, error) {
+	t, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// BindStringOrFile binds string into ptr with a optional default value. A
+// value of the form "@path" binds the contents of the file at path instead,
+// with leading and trailing white space trimmed, while Value of the returned
+// Env keeps the reference. If the file cannot be read, the default is bound
+// instead, and the error is returned.
+func (n *Namespace) BindStringOrFile(name string, ptr *string, def ...string) (*Env, error) {
+	e := n.newText(name)
+	if len(def) > 0 {
+		e.def = def[0]
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := readStringOrFile(e.Value)
+	if err != nil {
+		n.invalid(e, err)
+		if ok && len(def) > 0 {
+			if v, err := readStringOrFile(def[0]); err == nil {
+				*ptr = v
+			}
+		}
+		return e, e.Err
+	}
+	*ptr = v
+	return e, nil
+}
+
+func readStringOrFile(s string) (string, error) {
+	path, ok := strings.CutPrefix(s, "@")
+	if !ok {
+		return s, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// BindRune binds a single character into ptr with a optional default value.
+// Escape sequences such as "\t", "\n" and "\u0000" are interpreted as by
+// strconv.UnquoteChar. Values of more than one character fall back to the
+// default.
+func (n *Namespace) BindRune(name string, ptr *rune, def ...rune) *Env {
+	e := n.new(name, "rune")
+	if len(def) > 0 {
+		e.def = formatRune(def[0])
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := parseRune(e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+func parseRune(s string) (rune, error) {
+	if s == "" {
+		return 0, errors.New("empty character")
+	}
+	v, _, tail, err := strconv.UnquoteChar(s, 0)
+	if err != nil {
+		return 0, err
+	}
+	if tail != "" {
+		return 0, errors.New("more than one character in " + strconv.Quote(s))
+	}
+	return v, nil
+}
+
+func formatRune(r rune) string {
+	if unicode.IsPrint(r) && r != '\\' {
+		return string(r)
+	}
+	q := strconv.QuoteRune(r)
+	return q[1 : len(q)-1]
+}
+
+// bindStringTransform binds string into ptr with a optional default value, after
+// applying fn to whichever is used. Value of the returned Env is left as is.
+func (n *Namespace) bindStringTransform(name string, fn func(string) string, ptr *string, def ...string) *Env {
+	e := n.newText(name)
+	e.set = func(v string) { *ptr = fn(v) }
+	if len(def) > 0 {
+		e.def = def[0]
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	*ptr = fn(e.Value)
+	return e
+}
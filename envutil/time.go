@@ -0,0 +1,296 @@
+package envutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BindDurationOrDisabled binds time.Duration into ptr with a optional default
+// value, like BindDuration, except that "off", "none", "disabled" and "0"
+// (case-insensitively) bind zero to disable whatever the duration controls.
+// Since Value of the returned Env keeps the token as given, an explicitly
+// disabled duration is distinguishable from one which is unset.
+func (n *Namespace) BindDurationOrDisabled(name string, ptr *time.Duration, def ...time.Duration) *Env {
+	e := n.new(name, "duration")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := parseDurationOrDisabled(e.Value)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// BindTimeoutDuration binds a timeout into ptr with a optional default value,
+// like BindDurationOrDisabled, except that zero, negative durations and the
+// disabling tokens bind disabled instead, so that "0" never means a zero
+// timeout. A default of zero or less binds disabled as well.
+func (n *Namespace) BindTimeoutDuration(name string, ptr *time.Duration, disabled time.Duration, def ...time.Duration) *Env {
+	e := n.BindDurationOrDisabled(name, ptr, def...)
+	if (e.Source == SourceEnv || len(def) > 0) && *ptr <= 0 {
+		*ptr = disabled
+	}
+	return e
+}
+
+// BindDurationClamp binds time.Duration into ptr with a optional default value,
+// like BindDuration, but clamps the duration into [min, max] rather than
+// rejecting it. Negative durations are clamped to min. Clamped of the
+// returned Env reports whether clamping occurred.
+func (n *Namespace) BindDurationClamp(name string, min, max time.Duration, ptr *time.Duration, def ...time.Duration) *Env {
+	e := n.new(name, "duration")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := time.ParseDuration(strings.TrimSpace(e.Value))
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	switch {
+	case v < min || v < 0:
+		v, e.Clamped = min, true
+	case v > max:
+		v, e.Clamped = max, true
+	}
+	*ptr = v
+	return e
+}
+
+// BindDurationInRange binds time.Duration between min and max inclusive into
+// ptr with a optional default value. Values out of range, and negative ones
+// unless enabled by Namespace.AllowNegativeDurations, are rejected like
+// malformed ones. It panics if the default is out of range, or negative, or
+// if min is negative, unless negative durations are enabled.
+func (n *Namespace) BindDurationInRange(name string, ptr *time.Duration, min, max time.Duration, def ...time.Duration) *Env {
+	e := n.new(name, "duration")
+	if min < 0 && !n.negative {
+		panic(fmt.Sprintf("envutil: negative minimum %v of %s without AllowNegativeDurations", min, e.Name))
+	}
+	if len(def) > 0 {
+		if def[0] < min || def[0] > max {
+			panic(fmt.Sprintf("envutil: default %v of %s out of range [%v, %v]", def[0], e.Name, min, max))
+		}
+		if def[0] < 0 && !n.negative {
+			panic(fmt.Sprintf("envutil: negative default %v of %s", def[0], e.Name))
+		}
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := time.ParseDuration(strings.TrimSpace(e.Value))
+	switch {
+	case err != nil:
+	case v < 0 && !n.negative:
+		err = fmt.Errorf("negative duration %v", v)
+	case v < min || v > max:
+		err = fmt.Errorf("%v out of range [%v, %v]", v, min, max)
+	}
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = v
+	return e
+}
+
+// Disabled reports whether e was explicitly set to a token which disables a
+// duration, as accepted by BindDurationOrDisabled.
+func (e *Env) Disabled() bool {
+	return isDisabledToken(e.Value)
+}
+
+func isDisabledToken(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "off", "none", "disabled", "0":
+		return true
+	}
+	return false
+}
+
+func parseDurationOrDisabled(s string) (time.Duration, error) {
+	if isDisabledToken(s) {
+		return 0, nil
+	}
+	return time.ParseDuration(strings.TrimSpace(s))
+}
+
+// BindTimeUnix binds time.Time into ptr with a optional default value. The
+// value is a Unix timestamp in seconds, such as "1735689600".
+func (n *Namespace) BindTimeUnix(name string, ptr *time.Time, def ...time.Time) *Env {
+	return n.bindTimeEpoch(name, time.Unix, time.Time.Unix, ptr, def...)
+}
+
+// BindTimeUnixMilli binds time.Time into ptr with a optional default value.
+// The value is a Unix timestamp in milliseconds, such as "1735689600000".
+func (n *Namespace) BindTimeUnixMilli(name string, ptr *time.Time, def ...time.Time) *Env {
+	return n.bindTimeEpoch(name, func(ms, _ int64) time.Time {
+		return time.UnixMilli(ms)
+	}, time.Time.UnixMilli, ptr, def...)
+}
+
+func (n *Namespace) bindTimeEpoch(name string, from func(int64, int64) time.Time, to func(time.Time) int64, ptr *time.Time, def ...time.Time) *Env {
+	e := n.new(name, "unix time")
+	if len(def) > 0 {
+		e.def = strconv.FormatInt(to(def[0]), 10)
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	i, err := strconv.ParseInt(strings.TrimSpace(e.Value), 10, 64)
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = from(i, 0)
+	return e
+}
+
+// BindWeekday binds time.Weekday into ptr with a optional default value. The
+// value is an English name such as "monday", its three-letter abbreviation
+// such as "mon", case-insensitively, or its index from 0 (Sunday) to 6.
+func (n *Namespace) BindWeekday(name string, ptr *time.Weekday, def ...time.Weekday) *Env {
+	e := n.new(name, "weekday")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := parseCalendarName(e.Value, 0, 6, func(i int) string { return time.Weekday(i).String() })
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = time.Weekday(v)
+	return e
+}
+
+// BindMonth binds time.Month into ptr with a optional default value. The value
+// is an English name such as "january", its three-letter abbreviation such as
+// "jan", case-insensitively, or its number from 1 to 12.
+func (n *Namespace) BindMonth(name string, ptr *time.Month, def ...time.Month) *Env {
+	e := n.new(name, "month")
+	if len(def) > 0 {
+		e.def = def[0].String()
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+	}
+
+BIND:
+	v, err := parseCalendarName(e.Value, 1, 12, func(i int) string { return time.Month(i).String() })
+	if err != nil {
+		if ok {
+			n.invalid(e, err)
+		}
+		if len(def) > 0 {
+			*ptr = def[0]
+		}
+		return e
+	}
+	*ptr = time.Month(v)
+	return e
+}
+
+// parseCalendarName parses s as the index between min and max, or the name
+// returned by nameOf for it, or the first three letters of that name.
+func parseCalendarName(s string, min, max int, nameOf func(int) string) (int, error) {
+	s = strings.TrimSpace(s)
+	if i, err := strconv.Atoi(s); err == nil {
+		if i < min || i > max {
+			return 0, fmt.Errorf("%d out of range [%d, %d]", i, min, max)
+		}
+		return i, nil
+	}
+	for i := min; i <= max; i++ {
+		name := nameOf(i)
+		if strings.EqualFold(s, name) || strings.EqualFold(s, name[:3]) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown name %q", s)
+}
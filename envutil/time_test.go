@@ -0,0 +1,33 @@
+package envutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBindDurationInRangeNegative(t *testing.T) {
+	tests := []struct {
+		name     string
+		negative bool
+		min      time.Duration
+		def      time.Duration
+		panics   bool
+	}{
+		{"negative min", false, -5 * time.Second, time.Second, true},
+		{"negative default", false, 0, -time.Second, true},
+		{"negative min allowed", true, -5 * time.Second, -time.Second, false},
+		{"positive", false, 0, time.Second, false},
+	}
+	for _, tt := range tests {
+		n := NewNamespaceWithLookup("app", mapLookup(nil)).AllowNegativeDurations(tt.negative)
+		var d time.Duration
+		func() {
+			defer func() {
+				if r := recover(); (r != nil) != tt.panics {
+					t.Errorf("%s: panic = %v, want panic %v", tt.name, r, tt.panics)
+				}
+			}()
+			n.BindDurationInRange("timeout", &d, tt.min, 5*time.Second, tt.def)
+		}()
+	}
+}
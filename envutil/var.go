@@ -0,0 +1,93 @@
+package envutil
+
+import (
+	"encoding"
+	"flag"
+	"reflect"
+)
+
+// Value is the interface to a value which parses itself from string. It is
+// identical to flag.Value, so that types implemented for command-line flags
+// can be bound as they are.
+type Value interface {
+	String() string
+	Set(string) error
+}
+
+// BindVar binds into v by calling its Set method, with the variable if set,
+// or else with the optional default value. Any error from Set is recorded in
+// Err of the returned Env. It panics if v is nil.
+func (n *Namespace) BindVar(name string, v Value, def ...string) *Env {
+	if isNil(v) {
+		panic("envutil: nil Value for " + n.key(name))
+	}
+	e := n.new(name, typeKind(reflect.TypeOf(v)))
+	if len(def) > 0 {
+		e.def = def[0]
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+		goto BIND
+	}
+	return e
+
+BIND:
+	if err := v.Set(e.Value); err != nil {
+		n.invalid(e, err)
+	}
+	return e
+}
+
+// BindFlagValue is like BindVar, but takes a flag.Value, and also returns Err
+// of the returned Env.
+func (n *Namespace) BindFlagValue(name string, v flag.Value, def ...string) (*Env, error) {
+	e := n.BindVar(name, v, def...)
+	return e, e.Err
+}
+
+// BindText binds into v by calling its UnmarshalText method, with the
+// variable if set, or else with the optional default value. Any error from
+// UnmarshalText is recorded in Err of the returned Env, and returned as a
+// ParseError naming the variable. It panics if v is nil.
+func (n *Namespace) BindText(name string, v encoding.TextUnmarshaler, def ...string) (*Env, error) {
+	if isNil(v) {
+		panic("envutil: nil TextUnmarshaler for " + n.key(name))
+	}
+	e := n.new(name, typeKind(reflect.TypeOf(v)))
+	if len(def) > 0 {
+		e.def = def[0]
+	}
+	val, ok := n.resolve(e)
+	if ok {
+		e.Value = val
+		goto BIND
+	}
+	if len(def) > 0 {
+		e.Source = SourceDefault
+		e.Value = e.def
+		goto BIND
+	}
+	return e, nil
+
+BIND:
+	if err := v.UnmarshalText([]byte(e.Value)); err != nil {
+		n.invalid(e, err)
+		return e, e.Err
+	}
+	return e, nil
+}
+
+// isNil reports whether v is nil, or a nil pointer.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
@@ -0,0 +1,27 @@
+package envutil
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestBindVarNil(t *testing.T) {
+	n := NewNamespaceWithLookup("app", mapLookup(nil))
+	defer func() {
+		if r := recover(); r != "envutil: nil Value for APP_TIMEOUT" {
+			t.Errorf("BindVar(nil): panic = %v", r)
+		}
+	}()
+	n.BindVar("timeout", nil)
+}
+
+func TestBindTextNil(t *testing.T) {
+	n := NewNamespaceWithLookup("app", mapLookup(nil))
+	defer func() {
+		if r := recover(); r != "envutil: nil TextUnmarshaler for APP_ADDR" {
+			t.Errorf("BindText(nil): panic = %v", r)
+		}
+	}()
+	var addr *netip.Addr
+	n.BindText("addr", addr)
+}
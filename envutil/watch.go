@@ -0,0 +1,68 @@
+package envutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Watch re-reads every variable bound by n, and every deprecated alias looked
+// up, at the given interval, and calls rebind whenever any of them was set,
+// unset or changed since last seen. It blocks until ctx is done, and returns
+// its error. It returns an error at once if interval is not positive.
+func (n *Namespace) Watch(ctx context.Context, interval time.Duration, rebind func()) error {
+	if interval <= 0 {
+		return fmt.Errorf("envutil: non-positive Watch interval %v", interval)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := n.snapshot()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		cur := n.snapshot()
+		if changedSince(last, cur) {
+			rebind()
+			// Pick up any variables bound for the first time by rebind.
+			cur = n.snapshot()
+		}
+		last = cur
+	}
+}
+
+type lookupResult struct {
+	value  string
+	exists bool
+}
+
+// snapshot looks up every variable bound by n, and every deprecated alias
+// looked up in its place, so that setting an alias triggers a rebind too.
+func (n *Namespace) snapshot() map[string]lookupResult {
+	envs := n.r.All()
+	m := make(map[string]lookupResult, len(envs))
+	for _, e := range envs {
+		val, ok := n.lookupEnv(e.Name)
+		m[e.Name] = lookupResult{val, ok}
+	}
+	for _, key := range n.r.usedKeys() {
+		if _, ok := m[key]; !ok {
+			val, ok := n.lookupEnv(key)
+			m[key] = lookupResult{val, ok}
+		}
+	}
+	return m
+}
+
+// changedSince reports whether any variable seen in last differs in cur.
+func changedSince(last, cur map[string]lookupResult) bool {
+	for k, v := range last {
+		if w, ok := cur[k]; ok && w != v {
+			return true
+		}
+	}
+	return false
+}
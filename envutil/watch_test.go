@@ -0,0 +1,28 @@
+package envutil
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWatchInterval(t *testing.T) {
+	n := NewNamespaceWithLookup("app", mapLookup(nil))
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := n.Watch(context.Background(), interval, func() {}); err == nil {
+			t.Errorf("Watch with interval %v: err = nil", interval)
+		}
+	}
+}
+
+func TestWatchAlias(t *testing.T) {
+	env := map[string]string{"APP_OLD_HOST": "a"}
+	n := NewNamespaceWithLookup("app", mapLookup(env))
+	var host string
+	n.BindStringAliases("host", []string{"old host"}, &host)
+	last := n.snapshot()
+	env["APP_OLD_HOST"] = "b"
+	if !changedSince(last, n.snapshot()) {
+		t.Error("changedSince = false after changing deprecated alias")
+	}
+}
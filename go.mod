@@ -1,3 +1,3 @@
 module github.com/universonic/turret
 
-go 1.14
+go 1.21